@@ -9,33 +9,26 @@ package main
 import (
 	"log"
 
-	ui "github.com/thzll/termuix"
+	uix "github.com/thzll/termuix"
 	"github.com/thzll/termuix/widgets"
 )
 
 func main() {
-	if err := ui.Init(); err != nil {
-		log.Fatalf("failed to initialize termui: %v", err)
-	}
-	defer ui.Close()
-
 	bc := widgets.NewBarChart()
 	bc.Data = []float64{3, 2, 5, 3, 9, 3}
 	bc.Labels = []string{"S0", "S1", "S2", "S3", "S4", "S5"}
 	bc.Title = "Bar Chart"
-	bc.SetRect(5, 5, 100, 25)
 	bc.BarWidth = 5
-	bc.BarColors = []ui.Color{ui.ColorRed, ui.ColorGreen}
-	bc.LabelStyles = []ui.Style{ui.NewStyle(ui.ColorBlue)}
-	bc.NumStyles = []ui.Style{ui.NewStyle(ui.ColorYellow)}
+	bc.BarColors = []uix.Color{uix.ColorRed, uix.ColorGreen}
+	bc.LabelStyles = []uix.Style{uix.NewStyle(uix.ColorBlue)}
+	bc.NumStyles = []uix.Style{uix.NewStyle(uix.ColorYellow)}
 
-	ui.Render(bc)
-	uiEvents := ui.PollEvents()
-	for {
-		e := <-uiEvents
-		switch e.ID {
-		case "q", "<C-c>":
-			return
-		}
+	ui, err := uix.New(bc)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	ui.SetQuitKeys("q", uix.KeyCtrlC)
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
 	}
 }
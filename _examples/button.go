@@ -0,0 +1,53 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	status := uix.NewLabel("Press a button")
+
+	var clicks int
+	count := uix.NewButton("Count")
+	count.SetMnemonic('c')
+	count.OnActivated(func(b *uix.Button) {
+		clicks++
+		status.SetText(fmt.Sprintf("Clicked %d times", clicks))
+	})
+
+	reset := uix.NewButton("Reset")
+	reset.SetMnemonic('r')
+	reset.OnActivated(func(b *uix.Button) {
+		clicks = 0
+		status.SetText("Press a button")
+	})
+
+	root := uix.NewVBox(
+		status,
+		uix.NewHBox(count, reset),
+	)
+
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+
+	// Tab moves the focus between the buttons; Enter or Space presses the
+	// focused one, and Alt+C / Alt+R press them from anywhere.
+	chain := &uix.SimpleFocusChain{}
+	chain.Set(count, reset)
+	ui.SetFocusChain(chain)
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	// Fall back to ASCII on terminals without the Unicode glyphs.
+	if os.Getenv("TERM") == "linux" {
+		uix.UseASCIIGlyphs()
+	}
+
+	status := uix.NewLabel("")
+
+	remember := uix.NewCheckbox("Remember me")
+	remember.OnStateChanged(func(c *uix.Checkbox) {
+		status.SetText(fmt.Sprintf("Remember me: %v", c.IsChecked()))
+	})
+
+	dark := uix.NewToggle("Dark mode")
+	dark.OnChanged(func(t *uix.Toggle) {
+		status.SetText(fmt.Sprintf("Dark mode: %v", t.IsOn()))
+	})
+
+	size := uix.NewRadioGroup("Small", "Medium", "Large")
+	size.SetSelected(1)
+	size.OnSelectionChanged(func(r *uix.RadioGroup) {
+		status.SetText("Size: " + r.SelectedOption())
+	})
+
+	root := uix.NewVBox(remember, dark, size, status)
+
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+
+	// Tab moves the focus between the inputs.
+	chain := &uix.SimpleFocusChain{}
+	chain.Set(remember, dark, size)
+	ui.SetFocusChain(chain)
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -11,28 +11,22 @@ import (
 	"math"
 	"time"
 
-	ui "github.com/gizak/termui/v3"
+	uix "github.com/thzll/termuix"
 	"github.com/thzll/termuix/widgets"
 )
 
 func main() {
-	if err := ui.Init(); err != nil {
-		log.Fatalf("failed to initialize termui: %v", err)
-	}
-	defer ui.Close()
-
 	p := widgets.NewParagraph()
 	p.Title = "Text Box"
 	p.Text = "PRESS q TO QUIT DEMO"
-	p.SetRect(0, 0, 50, 5)
-	p.TextStyle.Fg = ui.ColorWhite
-	p.BorderStyle.Fg = ui.ColorCyan
+	p.TextStyle.Fg = uix.ColorWhite
+	p.BorderStyle.Fg = uix.ColorCyan
 
 	updateParagraph := func(count int) {
 		if count%2 == 0 {
-			p.TextStyle.Fg = ui.ColorRed
+			p.TextStyle.Fg = uix.ColorRed
 		} else {
-			p.TextStyle.Fg = ui.ColorWhite
+			p.TextStyle.Fg = uix.ColorWhite
 		}
 	}
 
@@ -50,34 +44,31 @@ func main() {
 	l := widgets.NewList()
 	l.Title = "List"
 	l.Rows = listData
-	l.SetRect(0, 5, 25, 12)
-	l.TextStyle.Fg = ui.ColorYellow
+	l.TextStyle.Fg = uix.ColorYellow
 
 	g := widgets.NewGauge()
 	g.Title = "Gauge"
 	g.Percent = 50
-	g.SetRect(0, 12, 50, 15)
-	g.BarColor = ui.ColorRed
-	g.BorderStyle.Fg = ui.ColorWhite
-	g.TitleStyle.Fg = ui.ColorCyan
+	g.BarColor = uix.ColorRed
+	g.BorderStyle.Fg = uix.ColorWhite
+	g.TitleStyle.Fg = uix.ColorCyan
 
 	sparklineData := []float64{4, 2, 1, 6, 3, 9, 1, 4, 2, 15, 14, 9, 8, 6, 10, 13, 15, 12, 10, 5, 3, 6, 1, 7, 10, 10, 14, 13, 6, 4, 2, 1, 6, 3, 9, 1, 4, 2, 15, 14, 9, 8, 6, 10, 13, 15, 12, 10, 5, 3, 6, 1, 7, 10, 10, 14, 13, 6, 4, 2, 1, 6, 3, 9, 1, 4, 2, 15, 14, 9, 8, 6, 10, 13, 15, 12, 10, 5, 3, 6, 1, 7, 10, 10, 14, 13, 6, 4, 2, 1, 6, 3, 9, 1, 4, 2, 15, 14, 9, 8, 6, 10, 13, 15, 12, 10, 5, 3, 6, 1, 7, 10, 10, 14, 13, 6}
 
 	sl := widgets.NewSparkline()
 	sl.Title = "srv 0:"
 	sl.Data = sparklineData
-	sl.LineColor = ui.ColorCyan
-	sl.TitleStyle.Fg = ui.ColorWhite
+	sl.LineColor = uix.ColorCyan
+	sl.TitleStyle.Fg = uix.ColorWhite
 
 	sl2 := widgets.NewSparkline()
 	sl2.Title = "srv 1:"
 	sl2.Data = sparklineData
-	sl2.TitleStyle.Fg = ui.ColorWhite
-	sl2.LineColor = ui.ColorRed
+	sl2.TitleStyle.Fg = uix.ColorWhite
+	sl2.LineColor = uix.ColorRed
 
 	slg := widgets.NewSparklineGroup(sl, sl2)
 	slg.Title = "Sparkline"
-	slg.SetRect(25, 5, 50, 12)
 
 	sinData := (func() []float64 {
 		n := 220
@@ -92,34 +83,39 @@ func main() {
 	lc.Title = "dot-marker Line Chart"
 	lc.Data = make([][]float64, 1)
 	lc.Data[0] = sinData
-	lc.SetRect(0, 15, 50, 25)
-	lc.AxesColor = ui.ColorWhite
-	lc.LineColors[0] = ui.ColorRed
+	lc.AxesColor = uix.ColorWhite
+	lc.LineColors = []uix.Color{uix.ColorRed}
 	lc.Marker = widgets.MarkerDot
 
 	barchartData := []float64{3, 2, 5, 3, 9, 5, 3, 2, 5, 8, 3, 2, 4, 5, 3, 2, 5, 7, 5, 3, 2, 6, 7, 4, 6, 3, 6, 7, 8, 3, 6, 4, 5, 3, 2, 4, 6, 4, 8, 5, 9, 4, 3, 6, 5, 3, 6}
 
 	bc := widgets.NewBarChart()
 	bc.Title = "Bar Chart"
-	bc.SetRect(50, 0, 75, 10)
 	bc.Labels = []string{"S0", "S1", "S2", "S3", "S4", "S5"}
-	bc.BarColors[0] = ui.ColorGreen
-	bc.NumStyles[0] = ui.NewStyle(ui.ColorBlack)
+	bc.BarColors = []uix.Color{uix.ColorGreen}
+	bc.NumStyles = []uix.Style{uix.NewStyle(uix.ColorBlack)}
 
 	lc2 := widgets.NewPlot()
 	lc2.Title = "braille-mode Line Chart"
 	lc2.Data = make([][]float64, 1)
 	lc2.Data[0] = sinData
-	lc2.SetRect(50, 15, 75, 25)
-	lc2.AxesColor = ui.ColorWhite
-	lc2.LineColors[0] = ui.ColorYellow
+	lc2.AxesColor = uix.ColorWhite
+	lc2.LineColors = []uix.Color{uix.ColorYellow}
 
 	p2 := widgets.NewParagraph()
 	p2.Text = "Hey!\nI am a borderless block!"
 	p2.Border = false
-	p2.SetRect(50, 10, 75, 10)
-	p2.TextStyle.Fg = ui.ColorMagenta
-	ui.Render(p, l, g, slg, lc, bc, lc2, p2)
+	p2.TextStyle.Fg = uix.ColorMagenta
+	root := uix.NewHBox(
+		uix.NewVBox(p, uix.NewHBox(l, slg), g, lc),
+		uix.NewVBox(bc, p2, lc2),
+	)
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	ui.SetQuitKeys("q", uix.KeyCtrlC)
+
 	draw := func(count int) {
 		g.Percent = count % 101
 		l.Rows = listData[count%9:]
@@ -128,26 +124,24 @@ func main() {
 		lc.Data[0] = sinData[count/2%220:]
 		lc2.Data[0] = sinData[2*count%220:]
 		bc.Data = barchartData[count/2%10:]
-
-		ui.Render(p, l, g, slg, lc, bc, lc2, p2)
 	}
 
 	tickerCount := 1
 	draw(tickerCount)
 	tickerCount++
-	uiEvents := ui.PollEvents()
-	ticker := time.NewTicker(time.Second).C
-	for {
-		select {
-		case e := <-uiEvents:
-			switch e.ID {
-			case "q", "<C-c>":
-				return
-			}
-		case <-ticker:
-			updateParagraph(tickerCount)
-			draw(tickerCount)
-			tickerCount++
+	// The widgets are changed on the UI goroutine, then drawn again.
+	go func() {
+		for range time.Tick(time.Second) {
+			ui.Update(func() {
+				updateParagraph(tickerCount)
+				draw(tickerCount)
+				tickerCount++
+				ui.Repaint()
+			})
 		}
+	}()
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
 	}
 }
@@ -0,0 +1,62 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	uix "github.com/thzll/termuix"
+	"github.com/thzll/termuix/widgets"
+)
+
+func main() {
+	list := widgets.NewList()
+	list.Title = "Processes"
+	for i := 0; i < 50; i++ {
+		list.Rows = append(list.Rows, fmt.Sprintf("[%d](fg:yellow) worker-%d", 1000+i, i))
+	}
+	list.SelectedRowStyle = uix.NewStyle(uix.ColorBlack, uix.ColorWhite)
+
+	gauge := widgets.NewGauge()
+	gauge.Title = "CPU"
+	gauge.Percent = 42
+
+	plot := widgets.NewPlot()
+	plot.Title = "Load"
+	var sine []float64
+	for i := 0; i < 100; i++ {
+		sine = append(sine, 1+math.Sin(float64(i)/5))
+	}
+	plot.Data = [][]float64{sine}
+
+	table := widgets.NewTable()
+	table.Title = "Disks"
+	table.Rows = [][]string{
+		{"Device", "Size", "Used"},
+		{"/dev/sda1", "512G", "61%"},
+		{"/dev/sdb1", "2T", "12%"},
+	}
+
+	// Focus the list to move the selection with the arrow keys; the mouse
+	// wheel scrolls whatever is under the pointer.
+	list.SetFocused(true)
+
+	root := uix.NewHBox(
+		list,
+		uix.NewVBox(gauge, plot, table),
+	)
+
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
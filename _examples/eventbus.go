@@ -0,0 +1,56 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	uix "github.com/thzll/termuix"
+)
+
+var NoteEvent = uix.NewEventType()
+
+func main() {
+	input := uix.NewInput()
+	input.SetHeight(3)
+	input.SetTitle("note")
+
+	detail := uix.NewLabel("")
+	detail.SetTitle("last note")
+	clock := uix.NewLabel("")
+	clock.SetHeight(3)
+
+	root := uix.NewVBox(clock, input, detail)
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+
+	// The input and the label only know about the topic.
+	input.OnSubmit(func(input *uix.Input) {
+		ui.Bus().Publish(uix.Event{Type: NoteEvent, ID: "note", Payload: input.Text()})
+		input.SetText("")
+	})
+	ui.Bus().Subscribe("note", detail, func(e uix.Event) {
+		detail.SetText(e.Payload.(string))
+	})
+	ui.Bus().Subscribe("tick", clock, func(e uix.Event) {
+		clock.SetText(fmt.Sprint(e.Payload))
+	})
+
+	go func() {
+		for t := range time.Tick(time.Second) {
+			ui.Bus().Publish(uix.Event{Type: NoteEvent, ID: "tick", Payload: t.Format(time.Kitchen)})
+		}
+	}()
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
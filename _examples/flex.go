@@ -0,0 +1,43 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	list := uix.NewLabel("list")
+	detail := uix.NewLabel("detail")
+
+	// The detail pane gets 2/3 of the width.
+	panes := uix.NewHBox(list, detail)
+	panes.SetFlex(list, uix.Flex{Grow: 1})
+	panes.SetFlex(detail, uix.Flex{Grow: 2})
+	panes.SetSpacing(1)
+
+	// A toolbar with its buttons pushed to the right and centered vertically.
+	ok := uix.NewLabel("OK")
+	cancel := uix.NewLabel("Cancel")
+	toolbar := uix.NewHBox(ok, cancel)
+	toolbar.SetHeight(5)
+	toolbar.SetFlex(ok, uix.Flex{Basis: uix.BasisAuto})
+	toolbar.SetFlex(cancel, uix.Flex{Basis: uix.BasisAuto})
+	toolbar.SetSpacing(2)
+	toolbar.SetJustify(uix.JustifyEnd)
+	toolbar.SetAlign(uix.AlignCenter)
+
+	ui, err := uix.New(uix.NewVBox(panes, toolbar))
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
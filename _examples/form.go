@@ -0,0 +1,64 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	host := uix.NewInput()
+	host.SetTitle("Host")
+	host.SetHeight(3)
+	host.SetMask(uix.MaskIPv4)
+	host.SetValidator(uix.ValidateIPv4)
+
+	date := uix.NewInput()
+	date.SetTitle("Start date")
+	date.SetHeight(3)
+	date.SetMask(uix.MaskDate)
+	date.SetValidator(uix.ValidateDate)
+
+	port := uix.NewSpinInput(1, 65535, 1)
+	port.SetTitle("Port")
+	port.SetHeight(3)
+	port.SetValue(8080)
+
+	status := uix.NewLabel("")
+
+	save := uix.NewButton("Save")
+	save.OnActivated(func(b *uix.Button) {
+		for _, in := range []*uix.Input{host, date, &port.Input} {
+			if err := in.Validate(); err != nil {
+				status.SetText("Please fix the fields in red")
+				return
+			}
+		}
+		status.SetText(fmt.Sprintf("Saved %s:%.0f from %s", host.Text(), port.Value(), date.Text()))
+	})
+
+	root := uix.NewVBox(host, date, port, save, status)
+
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+
+	// Inputs start out focused; only the first one of the chain should be.
+	date.SetFocused(false)
+	port.SetFocused(false)
+	chain := &uix.SimpleFocusChain{}
+	chain.Set(host, date, port, save)
+	ui.SetFocusChain(chain)
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
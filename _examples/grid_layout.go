@@ -0,0 +1,47 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	header := uix.NewLabel("header")
+	sidebar := uix.NewLabel("sidebar")
+	content := uix.NewLabel("content")
+	footer := uix.NewLabel("footer")
+
+	// A nested grid splits the sidebar cell in two.
+	side := uix.NewGrid(
+		[]uix.Track{uix.RatioTrack(1), uix.RatioTrack(1)},
+		[]uix.Track{uix.RatioTrack(1)},
+	)
+	side.SetBorder(false)
+	side.Append(sidebar)
+	side.Append(uix.NewLabel("tools"))
+
+	grid := uix.NewGrid(
+		[]uix.Track{uix.FixedTrack(3), uix.RatioTrack(1), uix.FixedTrack(3)},
+		[]uix.Track{uix.AutoTrack(), uix.RatioTrack(2), uix.RatioTrack(1)},
+	)
+	grid.SetGap(0, 1)
+	grid.Add(header, 0, 0, 1, 3)
+	grid.Add(side, 1, 0, 1, 1)
+	grid.Add(content, 1, 1, 1, 2)
+	grid.Add(footer, 2, 0, 1, 3)
+
+	ui, err := uix.New(grid)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	uix "github.com/thzll/termuix"
+	"github.com/thzll/termuix/widgets"
+)
+
+var levels = []string{"[INFO](fg:green)", "[WARN](fg:yellow)", "[ERROR](fg:red)"}
+
+func main() {
+	// A million rows to start with; only the ones on screen are ever drawn.
+	rows := make([][]string, 0, 1000000)
+	for i := 0; i < cap(rows); i++ {
+		rows = append(rows, []string{fmt.Sprintf("%7d %s request served", i, levels[i%3])})
+	}
+	src := widgets.NewStreamSource(rows...)
+
+	l := widgets.NewList()
+	l.Title = "Log (End follows new lines, / filters)"
+	l.SetSource(src)
+	l.ScrollBottom()
+
+	ui, err := uix.New(l)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	l.SetFocused(true)
+
+	// Rows may be appended from any goroutine: the list takes them in on
+	// the UI goroutine, once for all the rows appended since it last did.
+	go func() {
+		for i := len(rows); ; i++ {
+			time.Sleep(time.Duration(rand.Intn(200)) * time.Millisecond)
+			src.AppendLine(fmt.Sprintf("%7d %s request served", i, levels[rand.Intn(3)]))
+		}
+	}()
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
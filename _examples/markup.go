@@ -0,0 +1,37 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	ui, err := uix.New(uix.NewLabel("loading"))
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+
+	// Edit markup.yaml while the example runs to see the layout change.
+	stop, err := ui.WatchView("markup.yaml", func(v *uix.View) {
+		query := v.Widget("query").(*uix.Input)
+		result := v.Widget("result")
+		query.OnSubmit(func(in *uix.Input) {
+			result.SetText("You searched for " + in.Text())
+		})
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stop()
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
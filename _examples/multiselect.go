@@ -0,0 +1,56 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	uix "github.com/thzll/termuix"
+	"github.com/thzll/termuix/widgets"
+)
+
+func main() {
+	l := widgets.NewList()
+	l.Title = "Packages (Space selects, / filters)"
+	l.MultiSelect = true
+	l.Rows = []string{
+		"[bash](fg:green)      GNU Bourne Again shell",
+		"[curl](fg:green)      command line URL tool",
+		"[git](fg:green)       distributed version control",
+		"[htop](fg:green)      interactive process viewer",
+		"[jq](fg:green)        command line JSON processor",
+		"[make](fg:green)      build automation tool",
+		"[ripgrep](fg:green)   recursive line search",
+		"[tmux](fg:green)      terminal multiplexer",
+		"[vim](fg:green)       text editor",
+		"[zsh](fg:green)       Z shell",
+	}
+
+	status := uix.NewLabel("")
+	l.OnSelectionChanged(func(l *widgets.List) {
+		var names []string
+		for _, i := range l.Selection() {
+			names = append(names, strings.Fields(uix.CellsToString(uix.ParseStyles(l.Rows[i], l.TextStyle)))[0])
+		}
+		status.SetText("Selected: " + strings.Join(names, ", "))
+	})
+	l.OnActivated(func(l *widgets.List) {
+		status.SetText(fmt.Sprintf("Activated row %d", l.SelectedRow))
+	})
+
+	ui, err := uix.New(uix.NewVBox(l, status))
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	l.SetFocused(true)
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	status := uix.NewLabel("Press Ctrl-P to open the command palette")
+	ui, err := uix.New(uix.NewVBox(status))
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+
+	for _, c := range []uix.Command{
+		{Name: "Open File", Category: "File", Description: "Open a file from disk", Keys: []string{uix.KeyCtrlO}},
+		{Name: "Save", Category: "File", Description: "Write the buffer to disk", Keys: []string{uix.KeyCtrlS}},
+		{Name: "Toggle Sidebar", Category: "View", Description: "Show or hide the sidebar"},
+		{Name: "Zoom In", Category: "View"},
+		{Name: "Zoom Out", Category: "View"},
+	} {
+		name := c.Name
+		c.Handler = func() { status.SetText("ran " + name) }
+		ui.RegisterCommand(c)
+	}
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
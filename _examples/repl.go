@@ -0,0 +1,57 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	uix "github.com/thzll/termuix"
+)
+
+var commands = []string{"cat", "cd", "echo", "help", "ls", "pwd"}
+
+func main() {
+	output := uix.NewLabel("Type a command. Up and Down recall earlier ones, Ctrl-R searches them.")
+	output.SetTitle("output")
+
+	history, err := uix.LoadHistory(filepath.Join(os.TempDir(), "termuix_repl_history"), 500)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The first word is a command, the others are file paths.
+	files := uix.NewPathCompleter()
+	words := uix.NewListCompleter(commands...)
+
+	prompt := uix.NewInput()
+	prompt.SetHeight(3)
+	prompt.SetTitle("$")
+	prompt.SetHistory(history)
+	prompt.SetCompleter(uix.CompleterFunc(func(text string) ([]string, int) {
+		if strings.ContainsAny(text, " \t") {
+			return files.Complete(text)
+		}
+		return words.Complete(text)
+	}))
+	prompt.OnSubmit(func(in *uix.Input) {
+		output.SetText("> " + in.Text())
+		in.SetText("")
+	})
+
+	root := uix.NewVBox(output, prompt)
+
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
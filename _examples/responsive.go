@@ -0,0 +1,35 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	nav := uix.NewLabel("navigation")
+	content := uix.NewLabel("content")
+	aside := uix.NewLabel("related")
+
+	// Resize the terminal to see the layout change.
+	page := uix.NewHBox(nav, content, aside)
+	page.AddBreakpoint(uix.Breakpoint{
+		MaxWidth: 30,
+		Replace:  uix.NewLabel("The terminal is too narrow."),
+	})
+	page.AddBreakpoint(uix.Breakpoint{MaxWidth: 80, Flip: true, Hide: []uix.Widget{aside}})
+
+	ui, err := uix.New(page)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	fruit := uix.NewSelect("Apple", "Banana", "Blueberry", "Cherry", "Date",
+		"Elderberry", "Fig", "Grape", "Kiwi", "Lemon", "Mango", "Orange")
+	fruit.SetTitle("Fruit")
+	fruit.SetHeight(3)
+
+	city := uix.NewSelect("Amsterdam", "Berlin", "Lisbon", "London", "Madrid", "Paris", "Rome")
+	city.SetTitle("City (or type your own)")
+	city.SetHeight(3)
+	city.SetCombo(true)
+
+	status := uix.NewLabel("")
+	update := func(*uix.Select) {
+		status.SetText(fmt.Sprintf("%s in %s", fruit.Value(), city.Value()))
+	}
+	fruit.OnValueChanged(update)
+	city.OnValueChanged(update)
+	update(nil)
+
+	root := uix.NewVBox(fruit, city, status)
+
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+
+	// Inputs start out focused; only the first one of the chain should be.
+	city.SetFocused(false)
+	chain := &uix.SimpleFocusChain{}
+	chain.Set(fruit, city)
+	ui.SetFocusChain(chain)
+
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	logs := uix.NewLabel("log")
+	detail := uix.NewLabel("detail")
+
+	// Drag the divider, or focus the splitter and use the arrow keys.
+	// Double-click the divider to collapse the smaller pane.
+	split := uix.NewVSplitter(logs, detail)
+	split.SetRatios(2, 1)
+	split.SetMinSize(0, 3)
+	split.SetMinSize(1, 3)
+	split.SetFocused(true)
+
+	ui, err := uix.New(split)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -9,16 +9,11 @@ package main
 import (
 	"log"
 
-	ui "github.com/gizak/termui/v3"
+	uix "github.com/thzll/termuix"
 	"github.com/thzll/termuix/widgets"
 )
 
 func main() {
-	if err := ui.Init(); err != nil {
-		log.Fatalf("failed to initialize termui: %v", err)
-	}
-	defer ui.Close()
-
 	sbc := widgets.NewStackedBarChart()
 	sbc.Title = "Student's Marks: X-Axis=Name, Y-Axis=Grade% (Math, English, Science, Computer Science)"
 	sbc.Labels = []string{"Ken", "Rob", "Dennis", "Linus"}
@@ -28,17 +23,14 @@ func main() {
 	sbc.Data[1] = []float64{70, 85, 75, 60}
 	sbc.Data[2] = []float64{75, 60, 80, 85}
 	sbc.Data[3] = []float64{100, 100, 100, 100}
-	sbc.SetRect(5, 5, 100, 30)
 	sbc.BarWidth = 5
 
-	ui.Render(sbc)
-
-	uiEvents := ui.PollEvents()
-	for {
-		e := <-uiEvents
-		switch e.ID {
-		case "q", "<C-c>":
-			return
-		}
+	ui, err := uix.New(sbc)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	ui.SetQuitKeys("q", uix.KeyCtrlC)
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
 	}
 }
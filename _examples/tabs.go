@@ -9,66 +9,63 @@ package main
 import (
 	"log"
 
-	ui "github.com/gizak/termui/v3"
+	uix "github.com/thzll/termuix"
 	"github.com/thzll/termuix/widgets"
 )
 
 func main() {
-	if err := ui.Init(); err != nil {
-		log.Fatalf("failed to initialize termui: %v", err)
-	}
-	defer ui.Close()
-
 	header := widgets.NewParagraph()
 	header.Text = "Press q to quit, Press h or l to switch tabs"
-	header.SetRect(0, 0, 50, 1)
+	header.SetHeight(1)
 	header.Border = false
-	header.TextStyle.Bg = ui.ColorBlue
+	header.TextStyle.Bg = uix.ColorBlue
 
 	p2 := widgets.NewParagraph()
 	p2.Text = "Press q to quit\nPress h or l to switch tabs\n"
 	p2.Title = "Keys"
-	p2.SetRect(5, 5, 40, 15)
-	p2.BorderStyle.Fg = ui.ColorYellow
+	p2.BorderStyle.Fg = uix.ColorYellow
 
 	bc := widgets.NewBarChart()
 	bc.Title = "Bar Chart"
 	bc.Data = []float64{3, 2, 5, 3, 9, 5, 3, 2, 5, 8, 3, 2, 4, 5, 3, 2, 5, 7, 5, 3, 2, 6, 7, 4, 6, 3, 6, 7, 8, 3, 6, 4, 5, 3, 2, 4, 6, 4, 8, 5, 9, 4, 3, 6, 5, 3, 6}
-	bc.SetRect(5, 5, 35, 10)
 	bc.Labels = []string{"S0", "S1", "S2", "S3", "S4", "S5"}
 
+	empty := widgets.NewParagraph()
+	empty.Border = false
+
 	tabpane := widgets.NewTabPane("pierwszy", "drugi", "trzeci", "żółw", "four", "five")
-	tabpane.SetRect(0, 1, 50, 4)
+	tabpane.SetHeight(3)
 	tabpane.Border = true
 
+	// content shows the widget of the active tab.
+	content := uix.NewVBox(p2)
 	renderTab := func() {
+		content.Remove(0)
 		switch tabpane.ActiveTabIndex {
 		case 0:
-			ui.Render(p2)
+			content.Append(p2)
 		case 1:
-			ui.Render(bc)
+			content.Append(bc)
+		default:
+			content.Append(empty)
 		}
+		tabpane.Refresh()
 	}
 
-	ui.Render(header, tabpane, p2)
-
-	uiEvents := ui.PollEvents()
-
-	for {
-		e := <-uiEvents
-		switch e.ID {
-		case "q", "<C-c>":
-			return
-		case "h":
-			tabpane.FocusLeft()
-			ui.Clear()
-			ui.Render(header, tabpane)
-			renderTab()
-		case "l":
-			tabpane.FocusRight()
-			ui.Clear()
-			ui.Render(header, tabpane)
-			renderTab()
-		}
+	ui, err := uix.New(uix.NewVBox(header, tabpane, content))
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	ui.SetQuitKeys("q", uix.KeyCtrlC)
+	ui.SetKeybinding("h", func() {
+		tabpane.FocusLeft()
+		renderTab()
+	})
+	ui.SetKeybinding("l", func() {
+		tabpane.FocusRight()
+		renderTab()
+	})
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
 	}
 }
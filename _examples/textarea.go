@@ -0,0 +1,39 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	uix "github.com/thzll/termuix"
+)
+
+func main() {
+	status := uix.NewLabel("")
+
+	notes := uix.NewTextArea()
+	notes.SetTitle("Notes")
+	notes.SetLineNumbers(true)
+	notes.SetMaxLength(500)
+	notes.SetFocused(true)
+	notes.OnChanged(func(t *uix.TextArea) {
+		pos := t.CursorPos()
+		status.SetText(fmt.Sprintf("Ln %d, Col %d, %d/500",
+			pos.Y+1, pos.X+1, len([]rune(t.Text()))))
+	})
+
+	root := uix.NewVBox(notes, status)
+
+	ui, err := uix.New(root)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -2,14 +2,14 @@
 // Use of this source code is governed by a MIT license that can
 // be found in the LICENSE file.
 
-/// +build ignore
+// +build ignore
 
 package main
 
 import (
 	"log"
 
-	ui "github.com/gizak/termui/v3"
+	uix "github.com/thzll/termuix"
 	"github.com/thzll/termuix/widgets"
 )
 
@@ -20,14 +20,9 @@ func (nv nodeValue) String() string {
 }
 
 func main() {
-	if err := ui.Init(); err != nil {
-		log.Fatalf("failed to initialize termui: %v", err)
-	}
-	defer ui.Close()
-
 	nodes := []*widgets.TreeNode{
 		{
-,			Value: nodeValue("Key 1"),
+			Value: nodeValue("Key 1"),
 			Nodes: []*widgets.TreeNode{
 				{
 					Value: nodeValue("Key 1.1"),
@@ -72,60 +67,35 @@ func main() {
 	}
 
 	l := widgets.NewTree()
-	l.TextStyle = ui.NewStyle(ui.ColorYellow)
+	l.TextStyle = uix.NewStyle(uix.ColorYellow)
 	l.WrapText = false
 	l.SetNodes(nodes)
 
-	x, y := ui.TerminalDimensions()
-
-	l.SetRect(0, 0, x, y)
-
-	ui.Render(l)
-
-	previousKey := ""
-	uiEvents := ui.PollEvents()
-	for {
-		e := <-uiEvents
-		switch e.ID {
-		case "q", "<C-c>":
-			return
-		case "j", "<Down>":
-			l.ScrollDown()
-		case "k", "<Up>":
-			l.ScrollUp()
-		case "<C-d>":
-			l.ScrollHalfPageDown()
-		case "<C-u>":
-			l.ScrollHalfPageUp()
-		case "<C-f>":
-			l.ScrollPageDown()
-		case "<C-b>":
-			l.ScrollPageUp()
-		case "g":
-			if previousKey == "g" {
-				l.ScrollTop()
-			}
-		case "<Home>":
-			l.ScrollTop()
-		case "<Enter>":
-			l.ToggleExpand()
-		case "G", "<End>":
-			l.ScrollBottom()
-		case "E":
-			l.ExpandAll()
-		case "C":
-			l.CollapseAll()
-		case "<Resize>":
-			x, y := ui.TerminalDimensions()
-			l.SetRect(0, 0, x, y)
-		}
+	ui, err := uix.New(l)
+	if err != nil {
+		log.Fatalf("failed to initialize termuix: %v", err)
+	}
+	ui.SetQuitKeys("q", uix.KeyCtrlC)
 
-		if previousKey == "g" {
-			previousKey = ""
-		} else {
-			previousKey = e.ID
-		}
+	// The focused tree handles the arrow keys, PgUp/PgDn, Home/End and
+	// Enter itself; the vi keys are bound on top of them.
+	l.SetFocused(true)
+	bind := func(seq string, fn func()) {
+		ui.SetKeybinding(seq, func() {
+			fn()
+			l.Refresh()
+		})
+	}
+	bind("j", l.ScrollDown)
+	bind("k", l.ScrollUp)
+	bind(uix.KeyCtrlD, l.ScrollHalfPageDown)
+	bind(uix.KeyCtrlU, l.ScrollHalfPageUp)
+	bind(uix.KeyCtrlF, l.ScrollPageDown)
+	bind(uix.KeyCtrlB, l.ScrollPageUp)
+	bind("E", l.ExpandAll)
+	bind("C", l.CollapseAll)
 
-		ui.Render(l)
+	if err := ui.Run(); err != nil {
+		log.Fatal(err)
 	}
 }
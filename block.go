@@ -83,8 +83,8 @@ func (s *Block) draw() {
 	}
 	if s.Title != "" {
 		min := s.GetParentMin()
-		min.X += int(s.MarginLeft) + 1
-		min.Y += int(s.MarginTop)
+		min.X += int(s.MarginLeft+s.X) + 1
+		min.Y += int(s.MarginTop + s.Y)
 		p.SetString(
 			s.Title,
 			s.TitleStyle,
@@ -93,6 +93,12 @@ func (s *Block) draw() {
 	}
 }
 
+// DrawFrame clears the block and draws its border and title. Widgets that
+// embed Block and paint their own content call it first in Draw.
+func (s *Block) DrawFrame() {
+	s.draw()
+}
+
 // Draw implements the Drawable interface.
 func (s *Block) Draw() {
 	s.Lock()
@@ -104,11 +110,22 @@ func (s *Block) Draw() {
 // SetBorder sets whether the border is visible or not.
 func (s *Block) SetBorder(enabled bool) {
 	s.Border = enabled
+	s.Invalidate()
 }
 
 // SetTitle sets the title of the box.
 func (s *Block) SetTitle(title string) {
 	s.Title = title
+	s.Invalidate()
+}
+
+// SetPadding sets the space between the border and the content of the box.
+func (s *Block) SetPadding(top, right, bottom, left int) {
+	s.PaddingTop = top
+	s.PaddingRight = right
+	s.PaddingBottom = bottom
+	s.PaddingLeft = left
+	s.Invalidate()
 }
 
 // GetRect implements the Drawable interface.
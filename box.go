@@ -4,13 +4,68 @@
 
 package termuix
 
+import "image"
+
+// Justify determines where the children of a Box are placed along its layout
+// direction when they don't fill it.
+type Justify int
+
+// Available justify options.
+const (
+	JustifyStart Justify = iota
+	JustifyCenter
+	JustifyEnd
+	JustifySpaceBetween
+)
+
+// Align determines how the children of a Box are sized and placed across its
+// layout direction.
+type Align int
+
+// Available align options.
+const (
+	// AlignStretch makes the children as large as the Box.
+	AlignStretch Align = iota
+	AlignStart
+	AlignCenter
+	AlignEnd
+)
+
+// Flex determines how a child of a Box shares the space along the layout
+// direction.
+type Flex struct {
+	// Grow is the share of the free space the child gets, relative to the
+	// other children.
+	Grow float64
+	// Shrink is how much the child gives up when space runs out, relative to
+	// the other children and weighted by their basis.
+	Shrink float64
+	// Basis is the size of the child before growing or shrinking. BasisAuto
+	// uses the size hint of the child.
+	Basis int
+}
+
+// BasisAuto is a Flex basis that uses the size hint of the child.
+const BasisAuto = -1
+
 type Box struct {
 	Block
+	flex    map[Widget]Flex
+	spacing int
+	justify Justify
+	align   Align
+
+	breakpoints      []Breakpoint
+	activeBreakpoint int
+	// base is the layout of the Box while a breakpoint is applied.
+	base *boxState
 }
 
 func NewHBox(c ...Widget) *Box {
 	b := &Box{
-		Block: *NewBlock(),
+		Block:            *NewBlock(),
+		flex:             make(map[Widget]Flex),
+		activeBreakpoint: -1,
 	}
 	for _, v := range c {
 		b.Append(v)
@@ -22,7 +77,9 @@ func NewHBox(c ...Widget) *Box {
 
 func NewVBox(c ...Widget) *Box {
 	b := &Box{
-		Block: *NewBlock(),
+		Block:            *NewBlock(),
+		flex:             make(map[Widget]Flex),
+		activeBreakpoint: -1,
 	}
 	for _, v := range c {
 		b.Append(v)
@@ -31,3 +88,252 @@ func NewVBox(c ...Widget) *Box {
 	b.style = NewStyle(ColorWhite)
 	return b
 }
+
+// SetFlex sets how w, a child of the Box, grows and shrinks.
+func (b *Box) SetFlex(w Widget, f Flex) {
+	b.flex[w] = f
+	b.Invalidate()
+}
+
+// SetSpacing sets the number of empty cells between the children.
+func (b *Box) SetSpacing(n int) {
+	b.spacing = n
+	b.Invalidate()
+}
+
+// SetJustify sets where the children are placed along the layout direction
+// when they don't fill the Box.
+func (b *Box) SetJustify(j Justify) {
+	b.justify = j
+	b.Invalidate()
+}
+
+// SetAlign sets how the children are sized and placed across the layout
+// direction.
+func (b *Box) SetAlign(a Align) {
+	b.align = a
+	b.Invalidate()
+}
+
+// Append adds the given widget at the end of the Box.
+func (b *Box) Append(w Widget) {
+	b.withoutBreakpoint(func() { b.WidgetBase.Append(w) })
+}
+
+// Prepend adds the given widget at the start of the Box.
+func (b *Box) Prepend(w Widget) {
+	b.withoutBreakpoint(func() { b.WidgetBase.Prepend(w) })
+}
+
+// Insert adds the widget into the Box at a given index.
+func (b *Box) Insert(i int, w Widget) {
+	b.withoutBreakpoint(func() { b.WidgetBase.Insert(i, w) })
+}
+
+// Remove deletes the widget from the Box at a given index.
+func (b *Box) Remove(i int) {
+	b.withoutBreakpoint(func() {
+		if i >= 0 && i < len(b.children) {
+			delete(b.flex, b.children[i])
+		}
+		b.WidgetBase.Remove(i)
+	})
+}
+
+// isFlex reports whether any of the flex options are in use. Otherwise the
+// children are laid out by their size policies alone.
+func (b *Box) isFlex() bool {
+	return len(b.flex) > 0 || b.spacing > 0 || b.justify != JustifyStart || b.align != AlignStretch
+}
+
+// Resize updates the size of the Box, applies the breakpoint matching the new
+// size and lays out its children.
+func (b *Box) Resize(pos image.Point, size image.Point) {
+	if b.applyBreakpoint(size) {
+		// Force the layout, which is skipped if the size is the same.
+		b.hints.dirty = true
+	}
+	if !b.isFlex() {
+		b.WidgetBase.Resize(pos, size)
+		return
+	}
+	if !b.needsLayout(pos, size) {
+		return
+	}
+	b.SetRect(pos.X, pos.Y, size.X, size.Y)
+	b.layoutFlex(b.GetInner().Size())
+}
+
+// ReLayout lays out the children again.
+func (b *Box) ReLayout() {
+	if !b.isFlex() {
+		b.WidgetBase.ReLayout()
+		return
+	}
+	b.layoutFlex(b.GetInner().Size())
+}
+
+// flexOf returns the flex of a child. Children without flex grow if their
+// size policy is Expanding.
+func (b *Box) flexOf(w Widget) Flex {
+	hint := dim(b.layout, constrainHint(w, sizeHintOf(w)))
+	if f, ok := b.flex[w]; ok {
+		if f.Basis == BasisAuto {
+			f.Basis = hint
+		}
+		return f
+	}
+	f := Flex{Shrink: 1, Basis: hint}
+	if alignedSizePolicy(b.layout, w) == Expanding {
+		f.Grow = 1
+	}
+	return f
+}
+
+// layoutFlex lays out the children within size, the inner size of the Box.
+// The sizes fixed by the constraints of the children are kept as they are
+// while the others grow and shrink.
+func (b *Box) layoutFlex(size image.Point) {
+	n := len(b.children)
+	if n == 0 {
+		return
+	}
+	main := dim(b.layout, size) - b.spacing*(n-1)
+	cross := size.Y
+	if b.layout == Vertical {
+		cross = size.X
+	}
+
+	s := newConstraintSolver(b.children, main, cross, b.layout, b.align == AlignStretch)
+	flexes := make([]Flex, n)
+	for i, w := range b.children {
+		along, _, _ := constraintsOf(w, b.layout)
+		if s.fixed(i) {
+			flexes[i] = Flex{Basis: s.sizes[i]}
+		} else if _, ok := b.flex[w]; !ok && along.Ratio > 0 {
+			// Ratio widgets share the space left by the others.
+			flexes[i] = Flex{Grow: along.Ratio, Shrink: 1}
+		} else {
+			flexes[i] = b.flexOf(w)
+		}
+		s.sizes[i] = flexes[i].Basis
+	}
+
+	free := s.rest()
+	if free > 0 {
+		weights := make([]float64, n)
+		for i, f := range flexes {
+			weights[i] = f.Grow
+		}
+		for i, d := range distribute(free, weights) {
+			along, _, _ := constraintsOf(b.children[i], b.layout)
+			d = along.clamp(s.sizes[i]+d) - s.sizes[i]
+			s.sizes[i] += d
+			free -= d
+		}
+	} else if free < 0 {
+		free = b.shrink(s.sizes, flexes, -free)
+	}
+	if free < 0 {
+		// Still too large: take the overflow from the fixed sizes as well.
+		s.shrinkFixed(-free)
+	}
+	sizes, crosses := s.finish()
+	free = s.rest()
+
+	// Place the children along the layout direction.
+	offset, gap := 0, b.spacing
+	if free > 0 {
+		switch b.justify {
+		case JustifyCenter:
+			offset = free / 2
+		case JustifyEnd:
+			offset = free
+		case JustifySpaceBetween:
+			if n > 1 {
+				gap += free / (n - 1)
+				offset = 0
+			}
+		}
+	}
+
+	for i, w := range b.children {
+		crossPos := 0
+		switch b.align {
+		case AlignCenter:
+			crossPos = (cross - crosses[i]) / 2
+		case AlignEnd:
+			crossPos = cross - crosses[i]
+		}
+		if b.layout == Horizontal {
+			w.Resize(image.Pt(offset, crossPos), image.Pt(sizes[i], crosses[i]))
+		} else {
+			w.Resize(image.Pt(crossPos, offset), image.Pt(crosses[i], sizes[i]))
+		}
+		offset += sizes[i] + gap
+	}
+}
+
+// shrink takes up to over cells from the children by their shrink factor
+// weighted by their basis, never going below their minimum size. It returns
+// the free space left, which is negative if the children still overflow.
+func (b *Box) shrink(sizes []int, flexes []Flex, over int) int {
+	mins := make([]int, len(sizes))
+	for i, w := range b.children {
+		mins[i] = dim(b.layout, constrainHint(w, minSizeHintOf(w)))
+	}
+	// Each round either takes all it needs or pins a child at its minimum.
+	for round := 0; over > 0 && round <= len(sizes); round++ {
+		weights := make([]float64, len(sizes))
+		for i, f := range flexes {
+			if sizes[i] > mins[i] {
+				weights[i] = f.Shrink * float64(f.Basis)
+			}
+		}
+		cuts := distribute(over, weights)
+		if SumIntSlice(cuts) == 0 {
+			break
+		}
+		for i, d := range cuts {
+			d = MinInt(d, sizes[i]-mins[i])
+			sizes[i] -= d
+			over -= d
+		}
+	}
+	return -over
+}
+
+// Draw clears the Box, including the space between its children, and draws
+// its children.
+func (b *Box) Draw() {
+	b.Lock()
+	defer b.Unlock()
+	if p := b.GetPainter(); p != nil {
+		p.Fill(Cell{' ', b.style}, b.GetOuter().Add(b.GetParentMin()))
+	}
+	b.Block.draw()
+	b.drawSubWidget()
+}
+
+// SizeHint returns the recommended size of the Box, including the spacing
+// between its children.
+func (b *Box) SizeHint() image.Point {
+	return b.addSpacing(b.WidgetBase.SizeHint())
+}
+
+// MinSizeHint returns the minimum size of the Box, including the spacing
+// between its children.
+func (b *Box) MinSizeHint() image.Point {
+	return b.addSpacing(b.WidgetBase.MinSizeHint())
+}
+
+func (b *Box) addSpacing(size image.Point) image.Point {
+	if n := len(b.children); n > 1 {
+		if b.layout == Horizontal {
+			size.X += b.spacing * (n - 1)
+		} else {
+			size.Y += b.spacing * (n - 1)
+		}
+	}
+	return size
+}
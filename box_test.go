@@ -0,0 +1,152 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+// hintWidget is a widget with fixed size hints.
+type hintWidget struct {
+	Block
+	hint, min image.Point
+}
+
+func newHintWidget(hint, min image.Point) *hintWidget {
+	w := &hintWidget{Block: *NewBlock(), hint: hint, min: min}
+	w.Border = false
+	return w
+}
+
+func (w *hintWidget) SizeHint() image.Point    { return w.hint }
+func (w *hintWidget) MinSizeHint() image.Point { return w.min }
+
+// outers returns the rectangles of ws.
+func outers(ws ...Widget) []image.Rectangle {
+	rs := make([]image.Rectangle, len(ws))
+	for i, w := range ws {
+		rs[i] = w.GetOuter()
+	}
+	return rs
+}
+
+func TestBoxFlex(t *testing.T) {
+	hint, min := image.Pt(10, 2), image.Pt(4, 1)
+	tests := []struct {
+		name  string
+		width int
+		setup func(b *Box, ws []Widget)
+		want  []image.Rectangle
+	}{
+		{
+			name:  "grow by weight",
+			width: 60,
+			setup: func(b *Box, ws []Widget) {
+				b.SetFlex(ws[0], Flex{Grow: 1, Basis: BasisAuto})
+				b.SetFlex(ws[1], Flex{Grow: 2, Basis: BasisAuto})
+				b.SetFlex(ws[2], Flex{Basis: BasisAuto})
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 20, 5), image.Rect(20, 0, 50, 5), image.Rect(50, 0, 60, 5),
+			},
+		},
+		{
+			name:  "fixed basis",
+			width: 40,
+			setup: func(b *Box, ws []Widget) {
+				b.SetFlex(ws[0], Flex{Basis: 5})
+				b.SetFlex(ws[1], Flex{Grow: 1, Basis: 0})
+				b.SetFlex(ws[2], Flex{Basis: 5})
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 5, 5), image.Rect(5, 0, 35, 5), image.Rect(35, 0, 40, 5),
+			},
+		},
+		{
+			name:  "shrink by weight and basis",
+			width: 26,
+			setup: func(b *Box, ws []Widget) {
+				b.SetFlex(ws[0], Flex{Shrink: 1, Basis: BasisAuto})
+				b.SetFlex(ws[1], Flex{Shrink: 2, Basis: BasisAuto})
+				b.SetFlex(ws[2], Flex{Basis: BasisAuto})
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 9, 5), image.Rect(9, 0, 16, 5), image.Rect(16, 0, 26, 5),
+			},
+		},
+		{
+			name:  "shrink stops at the minimum",
+			width: 20,
+			setup: func(b *Box, ws []Widget) {
+				b.SetFlex(ws[0], Flex{Shrink: 1, Basis: BasisAuto})
+				b.SetFlex(ws[1], Flex{Shrink: 9, Basis: BasisAuto})
+				b.SetFlex(ws[2], Flex{Shrink: 1, Basis: BasisAuto})
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 8, 5), image.Rect(8, 0, 12, 5), image.Rect(12, 0, 20, 5),
+			},
+		},
+		{
+			name:  "spacing",
+			width: 34,
+			setup: func(b *Box, ws []Widget) {
+				b.SetSpacing(2)
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 10, 5), image.Rect(12, 0, 22, 5), image.Rect(24, 0, 34, 5),
+			},
+		},
+		{
+			name:  "justify center",
+			width: 40,
+			setup: func(b *Box, ws []Widget) {
+				b.SetJustify(JustifyCenter)
+				for _, w := range ws {
+					b.SetFlex(w, Flex{Basis: BasisAuto})
+				}
+			},
+			want: []image.Rectangle{
+				image.Rect(5, 0, 15, 5), image.Rect(15, 0, 25, 5), image.Rect(25, 0, 35, 5),
+			},
+		},
+		{
+			name:  "justify space between",
+			width: 40,
+			setup: func(b *Box, ws []Widget) {
+				b.SetJustify(JustifySpaceBetween)
+				for _, w := range ws {
+					b.SetFlex(w, Flex{Basis: BasisAuto})
+				}
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 10, 5), image.Rect(15, 0, 25, 5), image.Rect(30, 0, 40, 5),
+			},
+		},
+		{
+			name:  "align end",
+			width: 30,
+			setup: func(b *Box, ws []Widget) {
+				b.SetAlign(AlignEnd)
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 3, 10, 5), image.Rect(10, 3, 20, 5), image.Rect(20, 3, 30, 5),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := []Widget{newHintWidget(hint, min), newHintWidget(hint, min), newHintWidget(hint, min)}
+			b := NewHBox(ws...)
+			b.Border = false
+			tt.setup(b, ws)
+			b.Resize(image.Point{}, image.Pt(tt.width, 5))
+			if got := outers(ws...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("children at %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
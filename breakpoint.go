@@ -0,0 +1,134 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+// Breakpoint changes the layout of a Box while the Box is at most a given
+// size. Breakpoints are applied by Resize, so the layout follows the size of
+// the terminal without any code in the application.
+type Breakpoint struct {
+	// MaxWidth and MaxHeight are the largest size of the Box the breakpoint
+	// applies to. Zero doesn't limit that dimension.
+	MaxWidth  int
+	MaxHeight int
+	// Flip lays out the children Vertical instead of Horizontal and the
+	// other way around.
+	Flip bool
+	// Hide removes the given children from the layout and from the focus
+	// traversal.
+	Hide []Widget
+	// Replace shows the given widget instead of all the children.
+	Replace Widget
+}
+
+// matches reports whether the breakpoint applies to a Box of the given size.
+func (bp Breakpoint) matches(size image.Point) bool {
+	return (bp.MaxWidth <= 0 || size.X <= bp.MaxWidth) &&
+		(bp.MaxHeight <= 0 || size.Y <= bp.MaxHeight) &&
+		(bp.MaxWidth > 0 || bp.MaxHeight > 0)
+}
+
+// boxState is the layout of a Box without breakpoints.
+type boxState struct {
+	layout   LayoutMode
+	children []Widget
+}
+
+// AddBreakpoint adds a breakpoint to the Box. When several breakpoints match
+// the size of the Box, the first one added is applied, so add the smallest
+// first.
+func (b *Box) AddBreakpoint(bp Breakpoint) {
+	b.breakpoints = append(b.breakpoints, bp)
+	if bp.Replace != nil {
+		bp.Replace.SetParent(b)
+	}
+	b.reapplyBreakpoint()
+}
+
+// ActiveBreakpoint returns the index of the breakpoint applied to the Box, or
+// -1 if there is none.
+func (b *Box) ActiveBreakpoint() int {
+	return b.activeBreakpoint
+}
+
+// applyBreakpoint applies the first breakpoint matching size, or restores the
+// Box if none does. It reports whether the layout of the Box changed.
+func (b *Box) applyBreakpoint(size image.Point) bool {
+	next := -1
+	for i, bp := range b.breakpoints {
+		if bp.matches(size) {
+			next = i
+			break
+		}
+	}
+	if next == b.activeBreakpoint {
+		return false
+	}
+
+	b.restoreBreakpoint()
+	b.Invalidate()
+	if next < 0 {
+		return true
+	}
+
+	bp := b.breakpoints[next]
+	b.base = &boxState{layout: b.layout, children: b.children}
+	b.activeBreakpoint = next
+	if bp.Flip {
+		if b.layout == Horizontal {
+			b.layout = Vertical
+		} else {
+			b.layout = Horizontal
+		}
+	}
+	if bp.Replace != nil {
+		b.children = []Widget{bp.Replace}
+		return true
+	}
+	b.children = make([]Widget, 0, len(b.base.children))
+	for _, w := range b.base.children {
+		if !containsWidget(bp.Hide, w) {
+			b.children = append(b.children, w)
+		}
+	}
+	return true
+}
+
+// restoreBreakpoint undoes the applied breakpoint, if any.
+func (b *Box) restoreBreakpoint() {
+	if b.base == nil {
+		return
+	}
+	b.layout = b.base.layout
+	b.children = b.base.children
+	b.base = nil
+	b.activeBreakpoint = -1
+}
+
+// withoutBreakpoint runs fn on the Box without its breakpoint, so fn changes
+// the children of the Box rather than those shown by the breakpoint.
+func (b *Box) withoutBreakpoint(fn func()) {
+	b.restoreBreakpoint()
+	fn()
+	b.reapplyBreakpoint()
+}
+
+// reapplyBreakpoint applies the breakpoint matching the current size, once
+// the Box has been given one.
+func (b *Box) reapplyBreakpoint() {
+	if b.Width > 0 || b.Height > 0 {
+		b.applyBreakpoint(image.Pt(b.Width, b.Height))
+	}
+}
+
+func containsWidget(ws []Widget, w Widget) bool {
+	for _, v := range ws {
+		if v == w {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"testing"
+)
+
+func TestTabSkipsChildrenHiddenByBreakpoint(t *testing.T) {
+	first, hidden, last := NewButton("first"), NewButton("hidden"), NewButton("last")
+	b := NewHBox(first, hidden, last)
+	b.AddBreakpoint(Breakpoint{MaxWidth: 40, Hide: []Widget{hidden}})
+
+	ui, err := newTcellUI(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &SimpleFocusChain{}
+	chain.Set(first, hidden, last)
+	ui.SetFocusChain(chain)
+	tab := Event{Type: KeyboardEvent, ID: KeyTab}
+
+	b.Resize(image.Point{}, image.Pt(30, 3))
+	ui.kbFocus.focusedWidget = first
+	ui.kbFocus.OnKeyEvent(tab)
+	if got := ui.kbFocus.focusedWidget; got != last {
+		t.Errorf("with the breakpoint Tab focused %v, want %v", got, last)
+	}
+
+	b.Resize(image.Point{}, image.Pt(80, 3))
+	ui.kbFocus.focusedWidget = first
+	ui.kbFocus.OnKeyEvent(tab)
+	if got := ui.kbFocus.focusedWidget; got != hidden {
+		t.Errorf("without the breakpoint Tab focused %v, want %v", got, hidden)
+	}
+}
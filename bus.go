@@ -0,0 +1,124 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "sync"
+
+// EventBus passes application defined events between widgets without them
+// knowing about each other. Events are published on a topic, which is the ID
+// of the Event. Publish may be called from any goroutine; handlers always run
+// on the UI goroutine.
+type EventBus struct {
+	mu      sync.Mutex
+	subs    map[string][]*subscription
+	pending []Event
+
+	// notify wakes up the UI goroutine when events are pending.
+	notify chan struct{}
+}
+
+type subscription struct {
+	owner    Widget
+	fn       func(e Event)
+	released bool
+}
+
+// NewEventBus returns a new EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subs:   make(map[string][]*subscription),
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// Subscribe calls fn for every event published on topic. The subscription is
+// released when owner is removed from the widget tree, or when the returned
+// function is called. A nil owner keeps the subscription for the lifetime of
+// the bus.
+func (b *EventBus) Subscribe(topic string, owner Widget, fn func(e Event)) func() {
+	sub := &subscription{owner: owner, fn: fn}
+
+	b.mu.Lock()
+	b.subs[topic] = append(b.subs[topic], sub)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(topic, func(s *subscription) bool { return s == sub })
+	}
+}
+
+// Publish queues e for the subscribers of the topic e.ID. It never blocks,
+// so it is safe to call from event handlers as well as other goroutines.
+func (b *EventBus) Publish(e Event) {
+	b.mu.Lock()
+	b.pending = append(b.pending, e)
+	b.mu.Unlock()
+
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Release drops the subscriptions owned by w or any of its descendants.
+func (b *EventBus) Release(w Widget) {
+	owners := make(map[Widget]bool)
+	walkWidgets(w, func(w Widget) bool {
+		owners[w] = true
+		return true
+	})
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for topic := range b.subs {
+		b.remove(topic, func(s *subscription) bool {
+			return s.owner != nil && owners[s.owner]
+		})
+	}
+}
+
+// remove drops the subscriptions on topic matching fn. b.mu must be held.
+func (b *EventBus) remove(topic string, fn func(s *subscription) bool) {
+	var kept []*subscription
+	for _, s := range b.subs[topic] {
+		if fn(s) {
+			s.released = true
+		} else {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) == 0 {
+		delete(b.subs, topic)
+	} else {
+		b.subs[topic] = kept
+	}
+}
+
+// dispatch delivers the pending events. It must be called from the UI
+// goroutine.
+func (b *EventBus) dispatch() {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for _, e := range pending {
+		b.mu.Lock()
+		subs := append([]*subscription(nil), b.subs[e.ID]...)
+		b.mu.Unlock()
+
+		for _, s := range subs {
+			// An earlier handler may have released the subscription.
+			b.mu.Lock()
+			released := s.released
+			b.mu.Unlock()
+			if !released {
+				s.fn(e)
+			}
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"strings"
+	"unicode"
+)
+
+var _ Widget = &Button{}
+
+// Button is a widget that can be pressed. It is activated with Enter or Space
+// when focused, by a mouse click, or with Alt and its mnemonic letter.
+type Button struct {
+	Block
+	text     string
+	mnemonic rune
+
+	disabled bool
+	hovered  bool
+	pressed  bool
+
+	onActivated func(*Button)
+}
+
+// NewButton returns a new Button with the given text.
+func NewButton(text string) *Button {
+	b := &Button{
+		Block: *NewBlock(),
+		text:  text,
+	}
+	b.sizePolicyX = Maximum
+	b.sizePolicyY = Maximum
+	return b
+}
+
+// Text returns the text of the button.
+func (b *Button) Text() string {
+	return b.text
+}
+
+// SetText sets the text of the button.
+func (b *Button) SetText(text string) {
+	b.text = text
+	b.Invalidate()
+	b.rePaint(b)
+}
+
+// SetMnemonic sets the letter that activates the button together with Alt.
+// The first occurrence of the letter in the text is underlined. A zero rune
+// removes the mnemonic. Mnemonics take precedence over the keys of the
+// focused widget.
+func (b *Button) SetMnemonic(r rune) {
+	b.mnemonic = unicode.ToLower(r)
+}
+
+// Mnemonic returns the mnemonic letter of the button.
+func (b *Button) Mnemonic() rune {
+	return b.mnemonic
+}
+
+// SetEnabled sets whether the button can be activated. A disabled button
+// ignores all events.
+func (b *Button) SetEnabled(enabled bool) {
+	b.disabled = !enabled
+	if b.disabled {
+		b.pressed = false
+		b.hovered = false
+	}
+	b.rePaint(b)
+}
+
+// IsEnabled returns whether the button can be activated.
+func (b *Button) IsEnabled() bool {
+	return !b.disabled
+}
+
+// OnActivated sets the function called when the button is activated.
+func (b *Button) OnActivated(fn func(*Button)) {
+	b.onActivated = fn
+}
+
+// Activate runs the OnActivated callback, unless the button is disabled.
+func (b *Button) Activate() {
+	if b.disabled {
+		return
+	}
+	if b.onActivated != nil {
+		b.onActivated(b)
+	}
+}
+
+// currentStyle returns the theme style of the state the button is in.
+func (b *Button) currentStyle() Style {
+	switch {
+	case b.disabled:
+		return Theme.Button.Disabled
+	case b.pressed:
+		return Theme.Button.Pressed
+	case b.hovered:
+		return Theme.Button.Hovered
+	case b.IsFocused():
+		return Theme.Button.Focused
+	}
+	return Theme.Button.Normal
+}
+
+// SizeHint returns the size of the text with a space on each side.
+func (b *Button) SizeHint() image.Point {
+	return image.Pt(stringWidth(b.text)+2, 1).Add(b.FrameSize())
+}
+
+// MinSizeHint returns the size of the text.
+func (b *Button) MinSizeHint() image.Point {
+	return image.Pt(stringWidth(b.text), 1).Add(b.FrameSize())
+}
+
+// Draw draws the button in the style of its current state, with the text
+// centered.
+func (b *Button) Draw() {
+	b.Lock()
+	defer b.Unlock()
+
+	p := b.GetPainter()
+	if p == nil {
+		return
+	}
+	style := b.currentStyle()
+	b.Block.draw()
+
+	inner := b.GetInnerRealPos()
+	p.Fill(Cell{' ', style}, inner)
+	x := inner.Min.X + MaxInt((inner.Dx()-stringWidth(b.text))/2, 0)
+	y := inner.Min.Y + (inner.Dy()-1)/2
+
+	underline := -1
+	if b.mnemonic != 0 {
+		underline = strings.IndexFunc(b.text, func(r rune) bool {
+			return unicode.ToLower(r) == b.mnemonic
+		})
+	}
+	for i, r := range b.text {
+		if x >= inner.Max.X {
+			break
+		}
+		st := style
+		if i == underline {
+			st.Modifier |= ModifierUnderline
+		}
+		p.SetCell(Cell{r, st}, image.Pt(x, y))
+		x += runeWidth(r)
+	}
+}
+
+// Keybindings returns the keys handled by the button.
+func (b *Button) Keybindings() []KeyHelp {
+	keys := []KeyHelp{
+		{[]string{KeyEnter, KeySpace}, "Activate the button"},
+	}
+	if b.mnemonic != 0 {
+		keys = append(keys, KeyHelp{[]string{"<M-" + string(b.mnemonic) + ">"}, "Activate the button"})
+	}
+	return keys
+}
+
+// DoEvent activates the button with Enter or Space when it's focused, with
+// its mnemonic, or when the mouse button is pressed and released over it.
+func (b *Button) DoEvent(e Event) bool {
+	if b.disabled {
+		return false
+	}
+	switch e.Type {
+	case KeyboardEvent:
+		switch {
+		case b.isMnemonic(e.ID):
+		case b.IsFocused() && (e.ID == KeyEnter || e.ID == KeySpace):
+		default:
+			return false
+		}
+		b.Activate()
+	case MouseEvent:
+		if !b.doMouseEvent(e) {
+			return false
+		}
+	default:
+		return false
+	}
+	b.rePaint(b)
+	return true
+}
+
+// isMnemonic reports whether the key id is Alt and the mnemonic letter.
+func (b *Button) isMnemonic(id string) bool {
+	if b.mnemonic == 0 || !strings.HasPrefix(id, "<M-") || !strings.HasSuffix(id, ">") {
+		return false
+	}
+	r := []rune(id[3 : len(id)-1])
+	return len(r) == 1 && unicode.ToLower(r[0]) == b.mnemonic
+}
+
+// activateMnemonic activates the enabled button within root whose mnemonic
+// is the key of e, skipping the layers below a modal layer. It reports
+// whether there was one. The UI calls it before passing the key to the
+// focused widget, so that a focused Input, which uses Alt with b, d and f,
+// doesn't keep buttons with these mnemonics from firing.
+func activateMnemonic(root Widget, e Event) bool {
+	if e.Type != KeyboardEvent || !strings.HasPrefix(e.ID, "<M-") {
+		return false
+	}
+	b := mnemonicButton(root, e.ID)
+	if b == nil {
+		return false
+	}
+	b.Activate()
+	b.rePaint(b)
+	return true
+}
+
+// mnemonicButton returns the first enabled button within root whose mnemonic
+// is the key id, trying the layers of a Stack from the top down.
+func mnemonicButton(root Widget, id string) *Button {
+	if b, ok := root.(*Button); ok && !b.disabled && b.isMnemonic(id) {
+		return b
+	}
+	children := root.Children()
+	if s, ok := root.(*Stack); ok {
+		layers := children[MaxInt(s.modalIndex(), 0):]
+		children = make([]Widget, len(layers))
+		for i, w := range layers {
+			children[len(layers)-1-i] = w
+		}
+	}
+	for _, child := range children {
+		if b := mnemonicButton(child, id); b != nil {
+			return b
+		}
+	}
+	return nil
+}
+
+// doMouseEvent tracks whether the pointer is over the button and whether it
+// is pressed. A press followed by a release over the button activates it.
+func (b *Button) doMouseEvent(e Event) bool {
+	m := e.Payload.(Mouse)
+	over := image.Pt(m.X, m.Y).In(realOuter(b))
+	changed := over != b.hovered
+	b.hovered = over
+
+	switch e.ID {
+	case "<MouseLeft>":
+		if over && !m.Drag {
+			b.pressed = true
+			return true
+		}
+		if b.pressed {
+			return true
+		}
+	case "<MouseRelease>":
+		if b.pressed {
+			b.pressed = false
+			if over {
+				b.Activate()
+			}
+			return true
+		}
+	}
+	if changed {
+		b.rePaint(b)
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "testing"
+
+func TestMnemonicBeforeFocusedInput(t *testing.T) {
+	input := NewInput()
+	input.SetText("two words")
+	input.SetFocused(true)
+	back := NewButton("Back")
+	back.SetMnemonic('b')
+	var activated int
+	back.OnActivated(func(*Button) { activated++ })
+
+	ui, err := newTcellUI(NewVBox(input, back))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.kbFocus.focusedWidget = input
+	ui.handleEvent(Event{Type: KeyboardEvent, ID: "<M-b>"})
+
+	if activated != 1 {
+		t.Errorf("<M-b> activated the button %d times, want once", activated)
+	}
+	// Alt with another letter is left to the Input.
+	ui.handleEvent(Event{Type: KeyboardEvent, ID: "<M-f>"})
+	if activated != 1 {
+		t.Error("<M-f> activated the button")
+	}
+}
+
+func TestMnemonicBelowModal(t *testing.T) {
+	below := NewButton("Back")
+	below.SetMnemonic('b')
+	dialog := NewButton("Bye")
+	dialog.SetMnemonic('b')
+	var got *Button
+	below.OnActivated(func(b *Button) { got = b })
+	dialog.OnActivated(func(b *Button) { got = b })
+
+	s := NewStack(below)
+	s.Push(dialog, Layer{Anchor: AnchorCenter, Modal: true})
+	ui, err := newTcellUI(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.handleEvent(Event{Type: KeyboardEvent, ID: "<M-b>"})
+	if got != dialog {
+		t.Errorf("<M-b> activated %v, want the button of the modal layer", got)
+	}
+}
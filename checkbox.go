@@ -0,0 +1,161 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+var _ Widget = &Checkbox{}
+
+// CheckState is the state of a Checkbox.
+type CheckState int
+
+const (
+	Unchecked CheckState = iota
+	Checked
+	// Indeterminate is shown when the option is neither on nor off, e.g. for
+	// a checkbox standing for a group of partly checked options. It can only
+	// be set by the application.
+	Indeterminate
+)
+
+// Checkbox is a widget with a box that can be checked or unchecked, followed
+// by a text. It is toggled with Enter or Space when focused, or by a click.
+type Checkbox struct {
+	Block
+	text  string
+	state CheckState
+
+	onStateChanged func(*Checkbox)
+}
+
+// NewCheckbox returns a new unchecked Checkbox.
+func NewCheckbox(text string) *Checkbox {
+	c := &Checkbox{
+		Block: *NewBlock(),
+		text:  text,
+	}
+	c.Border = false
+	c.sizePolicyY = Minimum
+	return c
+}
+
+// Text returns the text of the checkbox.
+func (c *Checkbox) Text() string {
+	return c.text
+}
+
+// SetText sets the text of the checkbox.
+func (c *Checkbox) SetText(text string) {
+	c.text = text
+	c.Invalidate()
+	c.rePaint(c)
+}
+
+// State returns the state of the checkbox.
+func (c *Checkbox) State() CheckState {
+	return c.state
+}
+
+// SetState sets the state of the checkbox.
+func (c *Checkbox) SetState(state CheckState) {
+	if state == c.state {
+		return
+	}
+	c.state = state
+	if c.onStateChanged != nil {
+		c.onStateChanged(c)
+	}
+	c.rePaint(c)
+}
+
+// IsChecked returns whether the checkbox is checked.
+func (c *Checkbox) IsChecked() bool {
+	return c.state == Checked
+}
+
+// SetChecked checks or unchecks the checkbox.
+func (c *Checkbox) SetChecked(checked bool) {
+	if checked {
+		c.SetState(Checked)
+	} else {
+		c.SetState(Unchecked)
+	}
+}
+
+// Toggle unchecks a checked checkbox and checks it otherwise.
+func (c *Checkbox) Toggle() {
+	c.SetChecked(c.state != Checked)
+}
+
+// OnStateChanged sets the function called when the state changes.
+func (c *Checkbox) OnStateChanged(fn func(*Checkbox)) {
+	c.onStateChanged = fn
+}
+
+func (c *Checkbox) glyph() string {
+	switch c.state {
+	case Checked:
+		return Theme.Checkbox.Checked
+	case Indeterminate:
+		return Theme.Checkbox.Indeterminate
+	}
+	return Theme.Checkbox.Unchecked
+}
+
+// SizeHint returns the size of the box and the text.
+func (c *Checkbox) SizeHint() image.Point {
+	return image.Pt(stringWidth(c.glyph())+1+stringWidth(c.text), 1).Add(c.FrameSize())
+}
+
+// MinSizeHint returns the size of the box.
+func (c *Checkbox) MinSizeHint() image.Point {
+	return image.Pt(stringWidth(c.glyph()), 1).Add(c.FrameSize())
+}
+
+// Draw draws the box and the text, highlighted when focused.
+func (c *Checkbox) Draw() {
+	c.Lock()
+	defer c.Unlock()
+
+	p := c.GetPainter()
+	if p == nil {
+		return
+	}
+	c.Block.draw()
+	style := Theme.Checkbox.Text
+	if c.IsFocused() {
+		style = Theme.Checkbox.Focused
+	}
+	inner := c.GetInnerRealPos()
+	p.PushClip(inner)
+	p.SetString(c.glyph()+" "+c.text, style, inner.Min)
+	p.PopClip()
+}
+
+// Keybindings returns the keys handled by the checkbox.
+func (c *Checkbox) Keybindings() []KeyHelp {
+	return []KeyHelp{
+		{[]string{KeyEnter, KeySpace}, "Check or uncheck"},
+	}
+}
+
+// DoEvent toggles the checkbox with Enter or Space when it's focused, or when
+// it's clicked.
+func (c *Checkbox) DoEvent(e Event) bool {
+	switch e.Type {
+	case KeyboardEvent:
+		if !c.IsFocused() || (e.ID != KeyEnter && e.ID != KeySpace) {
+			return false
+		}
+	case MouseEvent:
+		if _, ok := clickedAt(c, e); !ok {
+			return false
+		}
+	default:
+		return false
+	}
+	c.Toggle()
+	return true
+}
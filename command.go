@@ -0,0 +1,135 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Command is a named action that can be run from a keybinding or from the
+// command palette.
+type Command struct {
+	// Name identifies the command and is shown in the palette.
+	Name string
+	// Description tells the user what the command does.
+	Description string
+	// Category groups related commands, e.g. "File" or "View".
+	Category string
+	// Keys are the key sequences bound to the command.
+	Keys []string
+	// Handler runs the command.
+	Handler func()
+}
+
+// title returns the label of the command shown to the user.
+func (c *Command) title() string {
+	if c.Category == "" {
+		return c.Name
+	}
+	return c.Category + ": " + c.Name
+}
+
+// commandRegistry holds the commands of a UI. The Keys of a stored command
+// are the keys in effect, which a keymap may have changed from the keys the
+// command was registered with.
+type commandRegistry struct {
+	commands map[string]*Command
+	defaults map[string][]string
+}
+
+func newCommandRegistry() *commandRegistry {
+	return &commandRegistry{
+		commands: make(map[string]*Command),
+		defaults: make(map[string][]string),
+	}
+}
+
+func (r *commandRegistry) add(c Command) {
+	r.defaults[c.Name] = c.Keys
+	r.commands[c.Name] = &c
+}
+
+func (r *commandRegistry) get(name string) (*Command, bool) {
+	c, ok := r.commands[name]
+	return c, ok
+}
+
+// list returns the commands sorted by category and name.
+func (r *commandRegistry) list() []*Command {
+	cmds := make([]*Command, 0, len(r.commands))
+	for _, c := range r.commands {
+		cmds = append(cmds, c)
+	}
+	sort.Slice(cmds, func(i, j int) bool {
+		if cmds[i].Category != cmds[j].Category {
+			return cmds[i].Category < cmds[j].Category
+		}
+		return cmds[i].Name < cmds[j].Name
+	})
+	return cmds
+}
+
+// RegisterCommand adds c to the command registry and binds it to c.Keys,
+// unless the current keymap assigns other keys to it. Registering a command
+// with the name of an existing command replaces it, including its
+// keybindings.
+func (ui *tcellUI) RegisterCommand(c Command) {
+	ui.commands.add(c)
+	if keys, ok := ui.keymap.keysFor(c.Name); ok {
+		c.Keys = keys
+	}
+	ui.bindCommand(c)
+}
+
+// bindCommand replaces the keybindings of c with bindings for c.Keys.
+func (ui *tcellUI) bindCommand(c Command) {
+	ui.removeCommandBindings(c.Name)
+	ui.commands.commands[c.Name] = &c
+	for _, seq := range c.Keys {
+		ui.keybindings = append(ui.keybindings, &keybinding{
+			sequence: seq,
+			handler:  c.Handler,
+			command:  c.Name,
+		})
+	}
+}
+
+// Commands returns the registered commands sorted by category and name.
+func (ui *tcellUI) Commands() []Command {
+	var cmds []Command
+	for _, c := range ui.commands.list() {
+		cmds = append(cmds, *c)
+	}
+	return cmds
+}
+
+// RunCommand runs the registered command with the given name.
+func (ui *tcellUI) RunCommand(name string) error {
+	c, ok := ui.commands.get(name)
+	if !ok {
+		return fmt.Errorf("termuix: unknown command %q", name)
+	}
+	if c.Handler != nil {
+		c.Handler()
+	}
+	return nil
+}
+
+// SetPaletteKey sets the key sequence that opens the command palette when the
+// focused widget doesn't handle it. An empty sequence disables the palette.
+func (ui *tcellUI) SetPaletteKey(seq string) {
+	ui.paletteKey = seq
+}
+
+func (ui *tcellUI) removeCommandBindings(name string) {
+	kept := ui.keybindings[:0]
+	for _, b := range ui.keybindings {
+		if b.command != name {
+			kept = append(kept, b)
+		}
+	}
+	ui.keybindings = kept
+}
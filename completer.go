@@ -0,0 +1,175 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Completer suggests completions for the text of an Input.
+type Completer interface {
+	// Complete returns the suggestions for text, the content of the Input
+	// before the cursor. Accepting a suggestion replaces text from the byte
+	// index start on.
+	Complete(text string) (suggestions []string, start int)
+}
+
+// CompleterFunc is a function used as a Completer.
+type CompleterFunc func(text string) ([]string, int)
+
+// Complete calls f(text).
+func (f CompleterFunc) Complete(text string) ([]string, int) {
+	return f(text)
+}
+
+// lastWord returns the byte index of the start of the last word of text,
+// words being separated by white space.
+func lastWord(text string) int {
+	return strings.LastIndexFunc(text, unicode.IsSpace) + 1
+}
+
+type listCompleter []string
+
+// NewListCompleter returns a Completer that completes the last word of the
+// text with the given words starting with it, ignoring case.
+func NewListCompleter(words ...string) Completer {
+	return listCompleter(words)
+}
+
+func (l listCompleter) Complete(text string) ([]string, int) {
+	start := lastWord(text)
+	prefix := strings.ToLower(text[start:])
+	if prefix == "" {
+		return nil, start
+	}
+	var matches []string
+	for _, w := range l {
+		if strings.HasPrefix(strings.ToLower(w), prefix) {
+			matches = append(matches, w)
+		}
+	}
+	return matches, start
+}
+
+type pathCompleter struct{}
+
+// NewPathCompleter returns a Completer that completes the last word of the
+// text as a file path. Directories are suggested with a trailing separator,
+// and hidden files only when the word starts with a dot.
+func NewPathCompleter() Completer {
+	return pathCompleter{}
+}
+
+func (pathCompleter) Complete(text string) ([]string, int) {
+	start := lastWord(text)
+	word := text[start:]
+	if word == "" {
+		return nil, start
+	}
+	dir, base := filepath.Split(word)
+	read := dir
+	if read == "" {
+		read = "."
+	}
+	files, err := ioutil.ReadDir(read)
+	if err != nil {
+		return nil, start
+	}
+	var matches []string
+	for _, f := range files {
+		name := f.Name()
+		if !strings.HasPrefix(name, base) || name[0] == '.' && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		if f.IsDir() {
+			name += string(filepath.Separator)
+		}
+		matches = append(matches, dir+name)
+	}
+	return matches, start
+}
+
+// complete shows the suggestions of the Completer for the text before the
+// cursor. A single suggestion that is already typed isn't shown.
+func (e *Input) complete() {
+	e.closeSuggestions()
+	if e.completer == nil {
+		return
+	}
+	text := string(e.text.Runes()[:e.text.Pos()])
+	suggestions, start := e.completer.Complete(text)
+	start = MaxInt(0, MinInt(start, len(text)))
+	if len(suggestions) == 1 && suggestions[0] == text[start:] {
+		return
+	}
+	e.suggestions.set(suggestions, -1)
+	e.suggestFrom = utf8.RuneCountInString(text[:start])
+}
+
+// completeWord completes the text before the cursor when Tab is pressed. A
+// single suggestion is accepted right away. It reports whether there was
+// anything to complete.
+func (e *Input) completeWord() bool {
+	e.complete()
+	switch len(e.suggestions.items) {
+	case 0:
+		return false
+	case 1:
+		e.acceptSuggestion(0)
+	}
+	return true
+}
+
+// acceptSuggestion replaces the text being completed with suggestion i.
+func (e *Input) acceptSuggestion(i int) {
+	if i < 0 || i >= len(e.suggestions.items) {
+		return
+	}
+	s := []rune(e.suggestions.items[i])
+	from, to := e.suggestFrom, e.text.Pos()
+	e.edit(editOther, func() {
+		e.text.DeleteRange(from, to)
+		e.text.WriteRunes(s)
+	})
+	e.closeSuggestions()
+}
+
+func (e *Input) closeSuggestions() {
+	e.suggestions.clear()
+}
+
+// doSuggestionKey handles a key while suggestions are shown. Enter only
+// accepts a suggestion once one is selected.
+func (e *Input) doSuggestionKey(ev Event) bool {
+	switch ev.ID {
+	case KeyArrowDown, KeyCtrlN:
+		e.suggestions.move(1, true)
+	case KeyArrowUp, KeyCtrlP:
+		e.suggestions.move(-1, true)
+	case KeyTab:
+		e.acceptSuggestion(MaxInt(e.suggestions.selected, 0))
+	case KeyEnter:
+		if e.suggestions.selected < 0 {
+			return false
+		}
+		e.acceptSuggestion(e.suggestions.selected)
+	case KeyEsc:
+		e.closeSuggestions()
+	default:
+		return false
+	}
+	return true
+}
+
+// drawSuggestions draws the suggestions below the Input, lined up with the
+// text they complete.
+func (e *Input) drawSuggestions(p *Painter) {
+	x := e.GetInnerRealPos().Min.X + e.columnOf(e.suggestFrom) - 1
+	e.suggestions.draw(p, realOuter(e), x, 0, Theme.Input.Suggestion, Theme.Input.SuggestionSelected)
+}
@@ -0,0 +1,380 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// Constraint restricts the size of a widget along one axis. Zero fields are
+// unset.
+type Constraint struct {
+	// Exact is the size in cells.
+	Exact int
+	// Percent is the size in percent of the inner size of the parent.
+	Percent float64
+	// Min and Max bound the size in cells.
+	Min, Max int
+	// Ratio is the share of the space left by the other widgets, relative to
+	// the ratios of the other widgets.
+	Ratio float64
+}
+
+// Constraints restricts the size of a widget.
+type Constraints struct {
+	Width, Height Constraint
+	// Aspect is the ratio of width to height, in cells. The size along the
+	// layout direction follows from the size across it.
+	Aspect float64
+}
+
+// ConstraintKind names the constraint setting the size of a widget.
+type ConstraintKind int
+
+// Available constraint kinds.
+const (
+	noConstraint ConstraintKind = iota
+	ConstraintExact
+	ConstraintPercent
+	ConstraintAspect
+	ConstraintMin
+)
+
+func (k ConstraintKind) String() string {
+	switch k {
+	case ConstraintExact:
+		return "exact"
+	case ConstraintPercent:
+		return "percent"
+	case ConstraintAspect:
+		return "aspect"
+	case ConstraintMin:
+		return "min"
+	}
+	return "none"
+}
+
+// ConstraintViolation is a constraint that couldn't be met because there was
+// not enough space.
+type ConstraintViolation struct {
+	// Axis is Horizontal for the width and Vertical for the height.
+	Axis LayoutMode
+	// Kind is the constraint that wasn't met.
+	Kind ConstraintKind
+	Want int
+	Got  int
+}
+
+func (v ConstraintViolation) String() string {
+	axis := "width"
+	if v.Axis == Vertical {
+		axis = "height"
+	}
+	return fmt.Sprintf("%s %s: want %d, got %d", v.Kind, axis, v.Want, v.Got)
+}
+
+// constrained is implemented by widgets embedding WidgetBase.
+type constrained interface {
+	SetConstraints(c Constraints)
+	Constraints() Constraints
+	setViolations(v []ConstraintViolation)
+}
+
+// SetConstraints sets the size constraints of the widget.
+func (w *WidgetBase) SetConstraints(c Constraints) {
+	w.constraints = c
+	w.Invalidate()
+}
+
+// Constraints returns the size constraints of the widget.
+func (w *WidgetBase) Constraints() Constraints {
+	return w.constraints
+}
+
+// ConstraintViolations returns the constraints that couldn't be met the last
+// time the widget was laid out.
+func (w *WidgetBase) ConstraintViolations() []ConstraintViolation {
+	return w.violations
+}
+
+func (w *WidgetBase) setViolations(v []ConstraintViolation) {
+	w.violations = v
+}
+
+func (c Constraint) isSet() bool {
+	return c != Constraint{}
+}
+
+// clamp bounds n by Min and Max.
+func (c Constraint) clamp(n int) int {
+	if c.Max > 0 && n > c.Max {
+		n = c.Max
+	}
+	if n < c.Min {
+		n = c.Min
+	}
+	return n
+}
+
+// constraintsOf returns the constraints along and across the layout
+// direction a.
+func constraintsOf(w Widget, a LayoutMode) (Constraint, Constraint, float64) {
+	cw, ok := w.(constrained)
+	if !ok {
+		return Constraint{}, Constraint{}, 0
+	}
+	c := cw.Constraints()
+	if a == Horizontal {
+		return c.Width, c.Height, c.Aspect
+	}
+	return c.Height, c.Width, c.Aspect
+}
+
+// hasConstraints reports whether any of ws has constraints.
+func hasConstraints(ws []Widget) bool {
+	for _, w := range ws {
+		if cw, ok := w.(constrained); ok && cw.Constraints() != (Constraints{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// constrainHint applies the constraints of w to a size hint.
+func constrainHint(w Widget, hint image.Point) image.Point {
+	cw, ok := w.(constrained)
+	if !ok {
+		return hint
+	}
+	c := cw.Constraints()
+	if c.Width.Exact > 0 {
+		hint.X = c.Width.Exact
+	}
+	if c.Height.Exact > 0 {
+		hint.Y = c.Height.Exact
+	}
+	return image.Pt(c.Width.clamp(hint.X), c.Height.clamp(hint.Y))
+}
+
+func percentOf(p float64, n int) int {
+	return int(math.Round(p * float64(n) / 100))
+}
+
+// aspectSize returns the size along the layout direction a of a widget with
+// the given aspect ratio and size across it.
+func aspectSize(cross int, aspect float64, a LayoutMode) int {
+	if a == Horizontal {
+		return int(math.Round(float64(cross) * aspect))
+	}
+	return int(math.Round(float64(cross) / aspect))
+}
+
+// constraintSolver lays out widgets with size constraints along the layout
+// direction a. It fixes the sizes set by exact, percent and aspect
+// constraints, leaves the others to the layout using it, and takes the space
+// the widgets don't fit in from the fixed sizes. Both the size policy layout
+// of WidgetBase and the flex layout of Box use it.
+type constraintSolver struct {
+	ws           []Widget
+	a, crossAxis LayoutMode
+	space, cross int
+	// sizes and crosses are the sizes of the widgets along and across a.
+	sizes, crosses []int
+	// want holds the sizes set by the constraints of kind.
+	want []int
+	kind []ConstraintKind
+}
+
+// newConstraintSolver sizes ws across a, within cross, and fixes their sizes
+// along a, within space, where a constraint sets them. Widgets that aren't
+// stretched across get their size hint there, unless constrained.
+func newConstraintSolver(ws []Widget, space, cross int, a LayoutMode, stretch bool) *constraintSolver {
+	n := len(ws)
+	s := &constraintSolver{
+		ws:        ws,
+		a:         a,
+		crossAxis: Vertical,
+		space:     space,
+		cross:     cross,
+		sizes:     make([]int, n),
+		crosses:   make([]int, n),
+		want:      make([]int, n),
+		kind:      make([]ConstraintKind, n),
+	}
+	if a == Vertical {
+		s.crossAxis = Horizontal
+	}
+	for i, w := range ws {
+		main, across, aspect := constraintsOf(w, a)
+
+		s.crosses[i] = cross
+		switch {
+		case across.Exact > 0:
+			s.crosses[i] = across.Exact
+		case across.Percent > 0:
+			s.crosses[i] = percentOf(across.Percent, cross)
+		case !stretch:
+			s.crosses[i] = dim(s.crossAxis, sizeHintOf(w))
+		}
+		s.crosses[i] = MinInt(across.clamp(s.crosses[i]), cross)
+
+		switch {
+		case main.Exact > 0:
+			s.want[i], s.kind[i] = main.Exact, ConstraintExact
+		case main.Percent > 0:
+			s.want[i], s.kind[i] = percentOf(main.Percent, space), ConstraintPercent
+		case aspect > 0:
+			s.want[i], s.kind[i] = aspectSize(s.crosses[i], aspect, a), ConstraintAspect
+		default:
+			continue
+		}
+		s.want[i] = main.clamp(s.want[i])
+		s.sizes[i] = s.want[i]
+	}
+	return s
+}
+
+// fixed reports whether the size of widget i is set by a constraint.
+func (s *constraintSolver) fixed(i int) bool {
+	return s.kind[i] != noConstraint
+}
+
+// rest returns the space not taken by the widgets, which is negative if they
+// don't fit.
+func (s *constraintSolver) rest() int {
+	return s.space - SumIntSlice(s.sizes)
+}
+
+// shrinkFixed takes up to over cells from the fixed sizes, first down to
+// their minimum and then below. It returns the cells it couldn't take.
+func (s *constraintSolver) shrinkFixed(over int) int {
+	// Each round either takes all it needs or brings a widget to its minimum.
+	for round := 0; over > 0 && round <= len(s.ws); round++ {
+		weights := make([]float64, len(s.ws))
+		for i := range s.ws {
+			if s.fixed(i) {
+				weights[i] = float64(s.aboveMin(i))
+			}
+		}
+		cuts := distribute(over, weights)
+		if SumIntSlice(cuts) == 0 {
+			break
+		}
+		for i, d := range cuts {
+			d = MinInt(d, s.aboveMin(i))
+			s.sizes[i] -= d
+			over -= d
+		}
+	}
+	if over > 0 {
+		// Still too large: go below the minimums as well.
+		weights := make([]float64, len(s.ws))
+		for i := range s.ws {
+			if s.fixed(i) {
+				weights[i] = float64(s.sizes[i])
+			}
+		}
+		for i, d := range distribute(over, weights) {
+			d = MinInt(d, s.sizes[i])
+			s.sizes[i] -= d
+			over -= d
+		}
+	}
+	return over
+}
+
+// aboveMin returns how far widget i is above its minimum size along a.
+func (s *constraintSolver) aboveMin(i int) int {
+	main, _, _ := constraintsOf(s.ws[i], s.a)
+	return MaxInt(s.sizes[i]-main.Min, 0)
+}
+
+// finish bounds the sizes that aren't fixed, records the constraints there
+// was no room for and returns the sizes along and across a. A widget keeping
+// its aspect ratio shrinks across instead of violating it.
+func (s *constraintSolver) finish() ([]int, []int) {
+	for i, w := range s.ws {
+		main, across, aspect := constraintsOf(w, s.a)
+		var violations []ConstraintViolation
+		switch {
+		case !s.fixed(i):
+			if got := main.clamp(s.sizes[i]); got > s.sizes[i] {
+				violations = append(violations, ConstraintViolation{s.a, ConstraintMin, main.Min, s.sizes[i]})
+			} else {
+				s.sizes[i] = got
+			}
+		case s.sizes[i] >= s.want[i]:
+		case s.kind[i] == ConstraintAspect:
+			if s.a == Horizontal {
+				s.crosses[i] = int(float64(s.sizes[i]) / aspect)
+			} else {
+				s.crosses[i] = int(float64(s.sizes[i]) * aspect)
+			}
+		default:
+			violations = append(violations, ConstraintViolation{s.a, s.kind[i], s.want[i], s.sizes[i]})
+		}
+		if across.Min > s.cross {
+			violations = append(violations, ConstraintViolation{s.crossAxis, ConstraintMin, across.Min, s.crosses[i]})
+		}
+		if cw, ok := w.(constrained); ok {
+			if len(violations) > 0 {
+				logger.Printf("Unmet constraints for %T: %v", w, violations)
+			}
+			cw.setViolations(violations)
+		}
+	}
+	return s.sizes, s.crosses
+}
+
+// solveConstraints returns the size of each widget along and across the
+// layout direction a, given the space along and across it. Widgets without
+// constraints along the layout direction share the space left by the others
+// according to their size policies.
+func solveConstraints(ws []Widget, space, cross int, a LayoutMode) ([]int, []int) {
+	s := newConstraintSolver(ws, space, cross, a, true)
+	s.shrinkFixed(-s.rest())
+	rem := MaxInt(s.rest(), 0)
+
+	var ratioIdx, freeIdx []int
+	var ratios []float64
+	for i, w := range ws {
+		if s.fixed(i) {
+			continue
+		}
+		if main, _, _ := constraintsOf(w, a); main.Ratio > 0 {
+			ratioIdx = append(ratioIdx, i)
+			ratios = append(ratios, main.Ratio)
+		} else {
+			freeIdx = append(freeIdx, i)
+		}
+	}
+	free := make([]Widget, len(freeIdx))
+	for j, i := range freeIdx {
+		free[j] = ws[i]
+	}
+	if len(ratioIdx) > 0 {
+		// Unconstrained widgets get no more than their size hint, the
+		// ratio widgets share the rest.
+		var hints int
+		for _, w := range free {
+			hints += dim(a, sizeHintOf(w))
+		}
+		freeSizes := doLayout(free, MinInt(hints, rem), a)
+		for j, i := range freeIdx {
+			s.sizes[i] = freeSizes[j]
+			rem -= freeSizes[j]
+		}
+		for j, d := range distribute(rem, ratios) {
+			s.sizes[ratioIdx[j]] = d
+		}
+	} else {
+		for j, d := range doLayout(free, rem, a) {
+			s.sizes[freeIdx[j]] = d
+		}
+	}
+	return s.finish()
+}
@@ -0,0 +1,192 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestDistribute(t *testing.T) {
+	tests := []struct {
+		total   int
+		weights []float64
+		want    []int
+	}{
+		{10, []float64{1, 1}, []int{5, 5}},
+		{10, []float64{1, 3}, []int{3, 7}},
+		{10, []float64{1, 1, 1}, []int{4, 3, 3}},
+		{7, []float64{0, 1, 0}, []int{0, 7, 0}},
+		{10, []float64{0, 0}, []int{0, 0}},
+		{-3, []float64{1, 1}, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		if got := distribute(tt.total, tt.weights); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("distribute(%d, %v) = %v, want %v", tt.total, tt.weights, got, tt.want)
+		}
+	}
+}
+
+// constrainedWidget returns a widget with a 10x2 size hint, a 4x1 minimum
+// size and the given constraints.
+func constrainedWidget(c Constraints) Widget {
+	w := newHintWidget(image.Pt(10, 2), image.Pt(4, 1))
+	w.SetConstraints(c)
+	return w
+}
+
+func TestSolveConstraints(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints []Constraints
+		space       int
+		cross       int
+		sizes       []int
+		crosses     []int
+		violations  [][]ConstraintViolation
+	}{
+		{
+			name: "exact, percent and free",
+			constraints: []Constraints{
+				{Width: Constraint{Exact: 10}},
+				{Width: Constraint{Percent: 25}},
+				{},
+			},
+			space:   80,
+			cross:   5,
+			sizes:   []int{10, 20, 50},
+			crosses: []int{5, 5, 5},
+		},
+		{
+			name: "ratios share the rest",
+			constraints: []Constraints{
+				{Width: Constraint{Exact: 10}},
+				{Width: Constraint{Ratio: 1}},
+				{Width: Constraint{Ratio: 3}},
+			},
+			space:   90,
+			cross:   5,
+			sizes:   []int{10, 20, 60},
+			crosses: []int{5, 5, 5},
+		},
+		{
+			name: "constraints across",
+			constraints: []Constraints{
+				{Height: Constraint{Exact: 2}},
+				{Height: Constraint{Percent: 50}},
+				{Height: Constraint{Max: 3}},
+			},
+			space:   30,
+			cross:   10,
+			sizes:   []int{10, 10, 10},
+			crosses: []int{2, 5, 3},
+		},
+		{
+			name:        "aspect",
+			constraints: []Constraints{{Aspect: 2}, {}},
+			space:       30,
+			cross:       5,
+			sizes:       []int{10, 20},
+			crosses:     []int{5, 5},
+		},
+		{
+			name:        "aspect shrinks across",
+			constraints: []Constraints{{Aspect: 2}},
+			space:       10,
+			cross:       10,
+			sizes:       []int{10},
+			crosses:     []int{5},
+		},
+		{
+			name: "overflow is taken down to the minimum first",
+			constraints: []Constraints{
+				{Width: Constraint{Exact: 30, Min: 20}},
+				{Width: Constraint{Exact: 30}},
+			},
+			space:   40,
+			cross:   5,
+			sizes:   []int{25, 15},
+			crosses: []int{5, 5},
+			violations: [][]ConstraintViolation{
+				{{Horizontal, ConstraintExact, 30, 25}},
+				{{Horizontal, ConstraintExact, 30, 15}},
+			},
+		},
+		{
+			name: "minimums",
+			constraints: []Constraints{
+				{Width: Constraint{Min: 30}},
+				{Height: Constraint{Min: 8}},
+			},
+			space:   20,
+			cross:   5,
+			sizes:   []int{10, 10},
+			crosses: []int{5, 5},
+			violations: [][]ConstraintViolation{
+				{{Horizontal, ConstraintMin, 30, 10}},
+				{{Vertical, ConstraintMin, 8, 5}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := make([]Widget, len(tt.constraints))
+			for i, c := range tt.constraints {
+				ws[i] = constrainedWidget(c)
+			}
+			sizes, crosses := solveConstraints(ws, tt.space, tt.cross, Horizontal)
+			if !reflect.DeepEqual(sizes, tt.sizes) {
+				t.Errorf("sizes = %v, want %v", sizes, tt.sizes)
+			}
+			if !reflect.DeepEqual(crosses, tt.crosses) {
+				t.Errorf("crosses = %v, want %v", crosses, tt.crosses)
+			}
+			for i, w := range ws {
+				var want []ConstraintViolation
+				if tt.violations != nil {
+					want = tt.violations[i]
+				}
+				if got := w.(*hintWidget).ConstraintViolations(); !reflect.DeepEqual(got, want) {
+					t.Errorf("violations of widget %d = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConstraintViolationString(t *testing.T) {
+	v := ConstraintViolation{Axis: Vertical, Kind: ConstraintPercent, Want: 12, Got: 7}
+	if got, want := v.String(), "percent height: want 12, got 7"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestBoxFlexKeepsConstraints(t *testing.T) {
+	exact := newHintWidget(image.Pt(10, 2), image.Pt(4, 1))
+	exact.SetConstraints(Constraints{Width: Constraint{Exact: 12}})
+	grow := newHintWidget(image.Pt(10, 2), image.Pt(4, 1))
+	b := NewHBox(exact, grow)
+	b.Border = false
+	b.SetFlex(grow, Flex{Grow: 1, Shrink: 1, Basis: BasisAuto})
+
+	b.Resize(image.Point{}, image.Pt(40, 3))
+	want := []image.Rectangle{image.Rect(0, 0, 12, 3), image.Rect(12, 0, 40, 3)}
+	if got := outers(exact, grow); !reflect.DeepEqual(got, want) {
+		t.Errorf("children at %v, want %v", got, want)
+	}
+
+	// Once the other child is at its minimum, the exact width gives way and
+	// the violation is recorded.
+	b.Resize(image.Point{}, image.Pt(10, 3))
+	want = []image.Rectangle{image.Rect(0, 0, 6, 3), image.Rect(6, 0, 10, 3)}
+	if got := outers(exact, grow); !reflect.DeepEqual(got, want) {
+		t.Errorf("children at %v, want %v", got, want)
+	}
+	v := exact.ConstraintViolations()
+	if len(v) != 1 || v[0].Kind != ConstraintExact || v[0].Want != 12 || v[0].Got != 6 {
+		t.Errorf("violations = %v, want exact width 12 got 6", v)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package termuix
+
+import (
+	"strings"
+
+	tb "github.com/nsf/termbox-go"
+)
+
+// shiftKeys are the xterm sequences of shifted movement keys, which termbox
+// doesn't decode.
+var shiftKeys = map[string]string{
+	"\x1b[1;2A": KeyShiftArrowUp,
+	"\x1b[1;2B": KeyShiftArrowDown,
+	"\x1b[1;2C": KeyShiftArrowRight,
+	"\x1b[1;2D": KeyShiftArrowLeft,
+	"\x1b[1;2H": KeyShiftHome,
+	"\x1b[1;2F": KeyShiftEnd,
+}
+
+// inputDecoder turns the raw input read from termbox into events. Decoding
+// the input here lets shifted keys and Alt through, which termbox reports as
+// an escape followed by separate keys. Input that may be the start of an
+// escape sequence or of a character is kept until more of it comes, or until
+// flush gives up waiting for it.
+type inputDecoder struct {
+	pending []byte
+}
+
+// feed returns the events in data, which follows the input kept from before.
+func (d *inputDecoder) feed(data []byte) []Event {
+	d.pending = append(d.pending, data...)
+	return d.decode(false)
+}
+
+// flush returns the events in the input kept waiting for more, and drops
+// what isn't an event on its own, like part of a character.
+func (d *inputDecoder) flush() []Event {
+	evs := d.decode(true)
+	d.pending = nil
+	return evs
+}
+
+// waiting reports whether input is kept waiting for more.
+func (d *inputDecoder) waiting() bool {
+	return len(d.pending) > 0
+}
+
+func (d *inputDecoder) decode(final bool) []Event {
+	var evs []Event
+	for len(d.pending) > 0 {
+		ev, n := decodeInput(d.pending, final)
+		if n == 0 {
+			break
+		}
+		d.pending = d.pending[n:]
+		if ev.ID != "" {
+			evs = append(evs, ev)
+		}
+	}
+	return evs
+}
+
+// decodeInput decodes the first event in buf. It returns the event and the
+// number of bytes it used, which is zero when buf ends in the middle of an
+// event. Unless final is set, an escape at the end of buf, or an escape
+// sequence that isn't terminated, counts as the middle of an event.
+func decodeInput(buf []byte, final bool) (Event, int) {
+	for seq, id := range shiftKeys {
+		if strings.HasPrefix(string(buf), seq) {
+			return Event{Type: KeyboardEvent, ID: id}, len(seq)
+		}
+	}
+	if buf[0] == '\x1b' && !final && incompleteEscape(buf) {
+		return Event{}, 0
+	}
+	e := tb.ParseEvent(buf)
+	if e.Type == tb.EventNone {
+		return Event{}, e.N
+	}
+	if e.Key == tb.KeyEsc && e.Ch == 0 && e.N == 1 && len(buf) > 2 && buf[1] == '[' {
+		// Drop unknown control sequences rather than typing them.
+		for i := 2; i < len(buf); i++ {
+			if isFinalByte(buf[i]) {
+				return Event{}, i + 1
+			}
+		}
+		// The rest of the sequence never came.
+		return Event{}, len(buf)
+	}
+	// An escape that isn't the start of a known sequence is Alt held
+	// down with the next key.
+	if e.Key == tb.KeyEsc && e.Ch == 0 && e.N == 1 && len(buf) > 1 {
+		if next := tb.ParseEvent(buf[1:]); next.Type == tb.EventKey {
+			next.Mod = tb.ModAlt
+			return convertTermboxEvent(next), next.N + 1
+		}
+	}
+	return convertTermboxEvent(e), e.N
+}
+
+// incompleteEscape reports whether buf, starting with an escape, may be the
+// start of an escape sequence of which more is to come: a lone escape, the
+// introducer of a CSI or SS3 sequence, or a CSI sequence without its final
+// byte.
+func incompleteEscape(buf []byte) bool {
+	if len(buf) == 1 {
+		return true
+	}
+	switch buf[1] {
+	case 'O':
+		return len(buf) == 2
+	case '[':
+		for _, b := range buf[2:] {
+			if isFinalByte(b) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isFinalByte reports whether b ends a CSI sequence.
+func isFinalByte(b byte) bool {
+	return b >= 0x40 && b <= 0x7e
+}
@@ -0,0 +1,101 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package termuix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func eventIDs(evs []Event) []string {
+	ids := []string{}
+	for _, ev := range evs {
+		ids = append(ids, ev.ID)
+	}
+	return ids
+}
+
+func TestInputDecoderFeed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"characters", "ab", []string{"a", "b"}},
+		{"wide character", "世", []string{"世"}},
+		{"shifted keys", "\x1b[1;2D\x1b[1;2H", []string{KeyShiftArrowLeft, KeyShiftHome}},
+		{"alt", "\x1bb", []string{"<M-b>"}},
+		{"alt after a key", "x\x1bf", []string{"x", "<M-f>"}},
+		{"unknown control sequence", "\x1b[99;5~a", []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d inputDecoder
+			got := eventIDs(d.feed([]byte(tt.input)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("feed(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if d.waiting() {
+				t.Errorf("feed(%q) keeps %q waiting", tt.input, d.pending)
+			}
+		})
+	}
+}
+
+func TestInputDecoderWaitsForSplitInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		pieces []string
+		want   []string
+	}{
+		{"shifted key", []string{"\x1b", "[1;", "2C"}, []string{KeyShiftArrowRight}},
+		{"alt", []string{"\x1b", "b"}, []string{"<M-b>"}},
+		{"character", []string{"\xe4\xb8", "\x96"}, []string{"世"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d inputDecoder
+			var got []Event
+			for i, p := range tt.pieces {
+				evs := d.feed([]byte(p))
+				if i < len(tt.pieces)-1 && len(evs) > 0 {
+					t.Fatalf("feed(%q) = %q before the rest came", p, eventIDs(evs))
+				}
+				got = append(got, evs...)
+			}
+			if ids := eventIDs(got); !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("got %q, want %q", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputDecoderFlush(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"escape", "\x1b", []string{KeyEsc}},
+		{"unterminated control sequence", "\x1b[1;2", []string{}},
+		{"part of a character", "a\xe4\xb8", []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d inputDecoder
+			got := d.feed([]byte(tt.input))
+			got = append(got, d.flush()...)
+			if ids := eventIDs(got); !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("got %q, want %q", ids, tt.want)
+			}
+			if d.waiting() {
+				t.Errorf("flush keeps %q waiting", d.pending)
+			}
+		})
+	}
+}
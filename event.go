@@ -6,6 +6,7 @@ package termuix
 
 import (
 	"fmt"
+	"sync"
 
 	tb "github.com/nsf/termbox-go"
 )
@@ -20,6 +21,7 @@ List of events:
 		<C-d> etc
 		<M-d> etc
 		<Up> <Down> <Left> <Right>
+		<S-Up> <S-Down> <S-Left> <S-Right> <S-Home> <S-End>
 		<Insert> <Delete> <Home> <End> <Previous> <Next>
 		<Backspace> <Tab> <Enter> <Escape> <Space>
 		<C-<Space>> etc
@@ -62,6 +64,13 @@ const (
 	KeyArrowLeft  = "<Left>"
 	KeyArrowRight = "<Right>"
 
+	KeyShiftArrowUp    = "<S-Up>"
+	KeyShiftArrowDown  = "<S-Down>"
+	KeyShiftArrowLeft  = "<S-Left>"
+	KeyShiftArrowRight = "<S-Right>"
+	KeyShiftHome       = "<S-Home>"
+	KeyShiftEnd        = "<S-End>"
+
 	KeyCtrlSpace  = "<C-<Space>>" //  KeyCtrl2  KeyCtrlTilde
 	KeyCtrlA      = "<C-a>"
 	KeyCtrlB      = "<C-b>"
@@ -108,6 +117,21 @@ const (
 	PaintEvent
 )
 
+var (
+	eventTypeMu   sync.Mutex
+	lastEventType = PaintEvent
+)
+
+// NewEventType returns a new EventType for application defined events. Every
+// call returns a type that differs from the built-in types and from all
+// previously returned types.
+func NewEventType() EventType {
+	eventTypeMu.Lock()
+	defer eventTypeMu.Unlock()
+	lastEventType++
+	return lastEventType
+}
+
 type Event struct {
 	Type    EventType
 	ID      string
@@ -130,11 +154,7 @@ type Resize struct {
 // PollEvents gets events from termbox, converts them, then sends them to each of its channels.
 func PollEvents() <-chan Event {
 	ch := make(chan Event)
-	go func() {
-		for {
-			ch <- convertTermboxEvent(tb.PollEvent())
-		}
-	}()
+	go pollEvents(ch)
 	return ch
 }
 
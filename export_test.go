@@ -0,0 +1,15 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+// DrainPosted runs the functions posted to p, as the UI goroutine does, and
+// returns how many there were.
+func DrainPosted(p *Painter) int {
+	fns := p.takePosted()
+	for _, fn := range fns {
+		fn()
+	}
+	return len(fns)
+}
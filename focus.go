@@ -15,19 +15,35 @@ type kbFocusController struct {
 	focusedWidget Widget
 
 	chain FocusChain
+	// onFocusChanged is called with the widget Tab moved the focus to.
+	onFocusChanged func(w Widget)
+	// skip reports whether Tab must pass over w, such as when w is hidden
+	// by a breakpoint or covered by a modal layer.
+	skip func(w Widget) bool
 }
 
-func (c *kbFocusController) OnKeyEvent(e Event) {
-	if c.chain == nil {
-		return
+// OnKeyEvent moves the focus along the chain when Tab is pressed, unless the
+// focused widget uses the key itself. It reports whether the key was handled.
+func (c *kbFocusController) OnKeyEvent(e Event) bool {
+	if c.chain == nil || c.focusedWidget == nil {
+		return false
 	}
 	switch e.ID {
 	case KeyTab:
-		if c.focusedWidget != nil {
-			c.focusedWidget.SetFocused(false)
-			c.focusedWidget = c.chain.FocusNext(c.focusedWidget)
-			c.focusedWidget.SetFocused(true)
+		if !c.skipped(c.focusedWidget) && c.focusedWidget.DoEvent(e) {
+			return true
 		}
+		next := c.next(c.focusedWidget)
+		if next == nil {
+			return false
+		}
+		c.focusedWidget.SetFocused(false)
+		c.focusedWidget = next
+		c.focusedWidget.SetFocused(true)
+		if c.onFocusChanged != nil {
+			c.onFocusChanged(next)
+		}
+		return true
 		//case KeyBacktab:
 		//	if c.focusedWidget != nil {
 		//		c.focusedWidget.SetFocused(false)
@@ -35,6 +51,41 @@ func (c *kbFocusController) OnKeyEvent(e Event) {
 		//		c.focusedWidget.SetFocused(true)
 		//	}
 	}
+	return false
+}
+
+// next returns the widget after w in the chain that isn't skipped, or nil if
+// there is none.
+func (c *kbFocusController) next(w Widget) Widget {
+	seen := map[Widget]bool{w: true}
+	for next := c.chain.FocusNext(w); next != nil && !seen[next]; next = c.chain.FocusNext(next) {
+		if !c.skipped(next) {
+			return next
+		}
+		seen[next] = true
+	}
+	return nil
+}
+
+func (c *kbFocusController) skipped(w Widget) bool {
+	return c.skip != nil && c.skip(w)
+}
+
+// scrollIntoView scrolls the ScrollViews between root and w, innermost
+// first, so that w is shown. It reports whether w is inside root.
+func scrollIntoView(root, w Widget) bool {
+	if root == w {
+		return true
+	}
+	for _, child := range root.Children() {
+		if scrollIntoView(child, w) {
+			if s, ok := root.(*ScrollView); ok {
+				s.showDescendant(w)
+			}
+			return true
+		}
+	}
+	return false
 }
 
 // DefaultFocusChain is the default focus chain.
@@ -0,0 +1,50 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "unicode"
+
+// fuzzyMatch reports whether the runes of pattern appear in text in the same
+// order, ignoring case. It also returns a score, higher for better matches,
+// and the indices of the runes in text that matched.
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	p := []rune(pattern)
+	t := []rune(text)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	var (
+		score   int
+		matched = make([]int, 0, len(p))
+		prev    = -2
+		pi      int
+	)
+	for i, r := range t {
+		if pi == len(p) {
+			break
+		}
+		if unicode.ToLower(r) != unicode.ToLower(p[pi]) {
+			continue
+		}
+		s := 1
+		// Consecutive runes and runes at the start of a word weigh more.
+		if i == prev+1 {
+			s += 5
+		}
+		if i == 0 || !unicode.IsLetter(t[i-1]) && !unicode.IsDigit(t[i-1]) {
+			s += 3
+		}
+		score += s
+		matched = append(matched, i)
+		prev = i
+		pi++
+	}
+	if pi < len(p) {
+		return 0, nil, false
+	}
+	// Prefer shorter texts between otherwise equal matches.
+	return score*100 - len(t), matched, true
+}
@@ -3,9 +3,11 @@ module github.com/thzll/termuix
 go 1.16
 
 require (
+	github.com/BurntSushi/toml v0.4.1
 	github.com/cjbassi/gotop v0.0.0-20200829004927-65d76af83079
 	github.com/gizak/termui/v3 v3.1.0
 	github.com/mattn/go-runewidth v0.0.13
 	github.com/mitchellh/go-wordwrap v1.0.1
 	github.com/nsf/termbox-go v1.1.1
+	gopkg.in/yaml.v2 v2.4.0
 )
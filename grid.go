@@ -0,0 +1,327 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+var _ Widget = &Grid{}
+
+// TrackKind determines how the size of a grid row or column is computed.
+type TrackKind int
+
+const (
+	// TrackFixed is a track of a fixed number of cells.
+	TrackFixed TrackKind = iota
+	// TrackRatio is a track taking a share of the space left over by the
+	// fixed and auto tracks, proportional to its ratio.
+	TrackRatio
+	// TrackAuto is a track as large as the size hints of its widgets.
+	TrackAuto
+)
+
+// Track is the size of a grid row or column.
+type Track struct {
+	Kind  TrackKind
+	Size  int
+	Ratio float64
+}
+
+// FixedTrack returns a track of n cells.
+func FixedTrack(n int) Track {
+	return Track{Kind: TrackFixed, Size: n}
+}
+
+// RatioTrack returns a track sharing the remaining space by ratio r.
+func RatioTrack(r float64) Track {
+	return Track{Kind: TrackRatio, Ratio: r}
+}
+
+// AutoTrack returns a track sized to fit its widgets.
+func AutoTrack() Track {
+	return Track{Kind: TrackAuto}
+}
+
+// gridCell is the area of the grid covered by a widget.
+type gridCell struct {
+	row, col         int
+	rowSpan, colSpan int
+}
+
+// Grid is a container laying out its widgets in rows and columns. A widget may
+// span several rows and columns, and grids may be nested in grids and boxes.
+type Grid struct {
+	Block
+	rows, cols     []Track
+	cells          map[Widget]gridCell
+	rowGap, colGap int
+}
+
+// NewGrid returns a new Grid with the given rows and columns.
+func NewGrid(rows, cols []Track) *Grid {
+	g := &Grid{
+		Block: *NewBlock(),
+		rows:  rows,
+		cols:  cols,
+		cells: make(map[Widget]gridCell),
+	}
+	g.style = NewStyle(ColorWhite)
+	return g
+}
+
+// SetRows sets the rows of the grid.
+func (g *Grid) SetRows(rows ...Track) {
+	g.rows = rows
+	g.Invalidate()
+}
+
+// SetColumns sets the columns of the grid.
+func (g *Grid) SetColumns(cols ...Track) {
+	g.cols = cols
+	g.Invalidate()
+}
+
+// SetGap sets the number of empty cells between rows and between columns.
+func (g *Grid) SetGap(row, col int) {
+	g.rowGap = row
+	g.colGap = col
+	g.Invalidate()
+}
+
+// Add places w at the given row and column, spanning rowSpan rows and colSpan
+// columns. Negative positions count as 0, and spans below 1 as 1.
+func (g *Grid) Add(w Widget, row, col, rowSpan, colSpan int) {
+	g.WidgetBase.Append(w)
+	g.place(w, row, col, rowSpan, colSpan)
+}
+
+// place sets the cells covered by w.
+func (g *Grid) place(w Widget, row, col, rowSpan, colSpan int) {
+	g.cells[w] = gridCell{MaxInt(row, 0), MaxInt(col, 0), MaxInt(rowSpan, 1), MaxInt(colSpan, 1)}
+}
+
+// Append places w in the first free cell, going row by row.
+func (g *Grid) Append(w Widget) {
+	g.Insert(len(g.children), w)
+}
+
+// Prepend places w in the first free cell like Append, and draws it before
+// the other widgets.
+func (g *Grid) Prepend(w Widget) {
+	g.Insert(0, w)
+}
+
+// Insert places w in the first free cell like Append, and draws it after the
+// first i widgets.
+func (g *Grid) Insert(i int, w Widget) {
+	if i < 0 || i > len(g.children) {
+		return
+	}
+	pt := g.freeCell()
+	g.WidgetBase.Insert(i, w)
+	g.place(w, pt.Y, pt.X, 1, 1)
+}
+
+// freeCell returns the column and row of the first cell not covered by a
+// widget, going row by row.
+func (g *Grid) freeCell() image.Point {
+	used := make(map[image.Point]bool)
+	for _, c := range g.cells {
+		for r := c.row; r < c.row+c.rowSpan; r++ {
+			for col := c.col; col < c.col+c.colSpan; col++ {
+				used[image.Pt(col, r)] = true
+			}
+		}
+	}
+	cols := len(g.cols)
+	if cols == 0 {
+		cols = 1
+	}
+	for i := 0; ; i++ {
+		if pt := image.Pt(i%cols, i/cols); !used[pt] {
+			return pt
+		}
+	}
+}
+
+// Remove deletes the widget at index i from the grid.
+func (g *Grid) Remove(i int) {
+	if i >= 0 && i < len(g.children) {
+		delete(g.cells, g.children[i])
+	}
+	g.WidgetBase.Remove(i)
+}
+
+// trackHints returns the size of each track along one axis, using hint to
+// size the widgets in auto and ratio tracks. Fixed tracks keep their size.
+func (g *Grid) trackHints(tracks []Track, horizontal bool, hint func(Widget) image.Point) []int {
+	sizes := make([]int, len(tracks))
+	for i, t := range tracks {
+		if t.Kind == TrackFixed {
+			sizes[i] = t.Size
+		}
+	}
+
+	span := func(c gridCell) (int, int) {
+		if horizontal {
+			return c.col, c.colSpan
+		}
+		return c.row, c.rowSpan
+	}
+	size := func(w Widget) int {
+		if horizontal {
+			return hint(w).X
+		}
+		return hint(w).Y
+	}
+	gap := g.rowGap
+	if horizontal {
+		gap = g.colGap
+	}
+
+	// Widgets in a single track size it directly...
+	for _, w := range g.children {
+		start, n := span(g.cells[w])
+		if n == 1 && start < len(tracks) && tracks[start].Kind != TrackFixed {
+			sizes[start] = MaxInt(sizes[start], size(w))
+		}
+	}
+	// ...while spanning widgets grow the last flexible track they cover.
+	for _, w := range g.children {
+		start, n := span(g.cells[w])
+		if n == 1 || start >= len(tracks) {
+			continue
+		}
+		end := MinInt(start+n, len(tracks))
+		have := (end - start - 1) * gap
+		last := -1
+		for i := start; i < end; i++ {
+			have += sizes[i]
+			if tracks[i].Kind != TrackFixed {
+				last = i
+			}
+		}
+		if need := size(w) - have; need > 0 && last >= 0 {
+			sizes[last] += need
+		}
+	}
+	return sizes
+}
+
+// solveTracks returns the size of each track along one axis for the given
+// space.
+func (g *Grid) solveTracks(tracks []Track, space int, horizontal bool) []int {
+	gap := g.rowGap
+	if horizontal {
+		gap = g.colGap
+	}
+	if len(tracks) > 1 {
+		space -= gap * (len(tracks) - 1)
+	}
+
+	sizes := g.trackHints(tracks, horizontal, sizeHintOf)
+	var weights []float64
+	var ratioIdx []int
+	for i, t := range tracks {
+		if t.Kind == TrackRatio {
+			weights = append(weights, t.Ratio)
+			ratioIdx = append(ratioIdx, i)
+			sizes[i] = 0
+		} else {
+			space -= sizes[i]
+		}
+	}
+
+	if space < 0 {
+		// Shrink the auto tracks towards their minimum size.
+		mins := g.trackHints(tracks, horizontal, minSizeHintOf)
+		for i, t := range tracks {
+			if t.Kind != TrackAuto || space >= 0 {
+				continue
+			}
+			d := MinInt(sizes[i]-mins[i], -space)
+			sizes[i] -= d
+			space += d
+		}
+	}
+
+	for i, n := range distribute(space, weights) {
+		sizes[ratioIdx[i]] = n
+	}
+	return sizes
+}
+
+// Resize lays out the widgets in their cells.
+func (g *Grid) Resize(pos image.Point, size image.Point) {
+	if !g.needsLayout(pos, size) {
+		return
+	}
+	g.SetRect(pos.X, pos.Y, size.X, size.Y)
+	inner := g.GetInner().Size()
+
+	colSizes := g.solveTracks(g.cols, inner.X, true)
+	rowSizes := g.solveTracks(g.rows, inner.Y, false)
+	colPos := trackPositions(colSizes, g.colGap)
+	rowPos := trackPositions(rowSizes, g.rowGap)
+
+	for _, w := range g.children {
+		c := g.cells[w]
+		if c.row >= len(rowSizes) || c.col >= len(colSizes) {
+			w.Resize(image.Pt(0, 0), image.Pt(0, 0))
+			continue
+		}
+		rowEnd := MinInt(c.row+c.rowSpan, len(rowSizes)) - 1
+		colEnd := MinInt(c.col+c.colSpan, len(colSizes)) - 1
+		min := image.Pt(colPos[c.col], rowPos[c.row])
+		max := image.Pt(colPos[colEnd]+colSizes[colEnd], rowPos[rowEnd]+rowSizes[rowEnd])
+		w.Resize(min, max.Sub(min))
+	}
+}
+
+// trackPositions returns the offset of each track.
+func trackPositions(sizes []int, gap int) []int {
+	pos := make([]int, len(sizes))
+	var x int
+	for i, s := range sizes {
+		pos[i] = x
+		x += s + gap
+	}
+	return pos
+}
+
+// gridSize returns the size of the grid with every track at the size given
+// by hint.
+func (g *Grid) gridSize(hint func(Widget) image.Point) image.Point {
+	total := func(sizes []int, gap int) int {
+		n := SumIntSlice(sizes)
+		if len(sizes) > 1 {
+			n += gap * (len(sizes) - 1)
+		}
+		return n
+	}
+	cols := g.trackHints(g.cols, true, hint)
+	rows := g.trackHints(g.rows, false, hint)
+	return image.Pt(total(cols, g.colGap), total(rows, g.rowGap)).Add(g.FrameSize())
+}
+
+// SizeHint returns the size at which every widget gets its size hint.
+func (g *Grid) SizeHint() image.Point {
+	return g.gridSize(sizeHintOf)
+}
+
+// MinSizeHint returns the size at which every widget gets its minimum size.
+func (g *Grid) MinSizeHint() image.Point {
+	return g.gridSize(minSizeHintOf)
+}
+
+// Draw clears the grid, including the gaps, and draws its widgets.
+func (g *Grid) Draw() {
+	g.Lock()
+	defer g.Unlock()
+	if p := g.GetPainter(); p != nil {
+		p.Fill(Cell{' ', g.style}, g.GetOuter().Add(g.GetParentMin()))
+	}
+	g.Block.draw()
+	g.drawSubWidget()
+}
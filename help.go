@@ -0,0 +1,333 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"reflect"
+	"strings"
+)
+
+// DefaultHelpKeys are the key sequences that toggle the keybinding help
+// unless SetHelpKeys is called.
+var DefaultHelpKeys = []string{KeyF1, "?"}
+
+// KeyHelp describes a key sequence handled by a widget.
+type KeyHelp struct {
+	Keys        []string
+	Description string
+}
+
+// KeybindingDescriber is implemented by widgets that handle keys themselves,
+// so that the keybinding help can list them.
+type KeybindingDescriber interface {
+	Keybindings() []KeyHelp
+}
+
+// helpScope is a group of bindings shown under a common heading.
+type helpScope struct {
+	name     string
+	bindings []KeyHelp
+}
+
+// helpScopes collects the active bindings of the UI, followed by the bindings
+// of every widget type in the widget tree.
+func (ui *tcellUI) helpScopes() []helpScope {
+	global := helpScope{name: "Global"}
+	if len(ui.quitKeys) > 0 {
+		global.bindings = append(global.bindings, KeyHelp{ui.quitKeys, "Quit"})
+	}
+	if len(ui.helpKeys) > 0 {
+		global.bindings = append(global.bindings, KeyHelp{ui.helpKeys, "Show this help"})
+	}
+	if ui.inspectorKey != "" {
+		global.bindings = append(global.bindings, KeyHelp{[]string{ui.inspectorKey}, "Toggle the layout inspector"})
+	}
+	if ui.paletteKey != "" {
+		global.bindings = append(global.bindings, KeyHelp{[]string{ui.paletteKey}, "Open the command palette"})
+	}
+	for _, b := range ui.keybindings {
+		desc := ""
+		if c, ok := ui.commands.get(b.command); ok {
+			desc = c.title()
+			if c.Description != "" {
+				desc = c.Description
+			}
+		}
+		global.bindings = append(global.bindings, KeyHelp{[]string{b.sequence}, desc})
+	}
+	scopes := []helpScope{global}
+
+	seen := make(map[string]bool)
+	walkWidgets(ui.root, func(w Widget) bool {
+		d, ok := w.(KeybindingDescriber)
+		if !ok {
+			return true
+		}
+		name := reflect.Indirect(reflect.ValueOf(w)).Type().Name()
+		if !seen[name] {
+			seen[name] = true
+			scopes = append(scopes, helpScope{name: name, bindings: d.Keybindings()})
+		}
+		return true
+	})
+	return scopes
+}
+
+// helpLine is a line of the keybinding help.
+type helpLine struct {
+	heading string
+	keys    string
+	desc    string
+}
+
+// helpOverlay lists the active keybindings grouped by scope. Typing / starts
+// a search that filters the bindings.
+type helpOverlay struct {
+	Block
+	ui     *tcellUI
+	search *Input
+
+	scopes    []helpScope
+	lines     []helpLine
+	keyWidth  int
+	top       int
+	searching bool
+}
+
+func newHelpOverlay(ui *tcellUI) *helpOverlay {
+	h := &helpOverlay{
+		Block:  *NewBlock(),
+		ui:     ui,
+		search: NewInput(),
+		scopes: ui.helpScopes(),
+	}
+	h.Title = "Keybindings"
+	h.BorderStyle = Theme.Help.Border
+	h.TitleStyle = Theme.Help.Border
+	h.search.Border = false
+	h.search.SetFocused(false)
+	h.search.OnChanged(func(*Input) {
+		h.filter()
+	})
+	h.Append(h.search)
+	h.filter()
+	return h
+}
+
+// filter rebuilds the lines from the bindings matching the search query.
+func (h *helpOverlay) filter() {
+	query := strings.ToLower(strings.TrimSpace(h.search.Text()))
+	h.lines = h.lines[:0]
+	h.keyWidth = 0
+	for _, s := range h.scopes {
+		var lines []helpLine
+		for _, b := range s.bindings {
+			keys := strings.Join(b.Keys, " ")
+			if query != "" &&
+				!strings.Contains(strings.ToLower(keys), query) &&
+				!strings.Contains(strings.ToLower(b.Description), query) {
+				continue
+			}
+			if w := stringWidth(keys); w > h.keyWidth {
+				h.keyWidth = w
+			}
+			lines = append(lines, helpLine{keys: keys, desc: b.Description})
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if len(h.lines) > 0 {
+			h.lines = append(h.lines, helpLine{})
+		}
+		h.lines = append(h.lines, helpLine{heading: s.name})
+		h.lines = append(h.lines, lines...)
+	}
+	h.top = 0
+}
+
+// Resize lays out the search line at the bottom of the overlay.
+func (h *helpOverlay) Resize(pos image.Point, size image.Point) {
+	h.SetRect(pos.X, pos.Y, size.X, size.Y)
+	inner := h.GetInner()
+	h.search.Resize(image.Pt(1, inner.Dy()-1), image.Pt(inner.Dx()-1, 1))
+	h.scroll(0)
+}
+
+// SizeHint returns the size needed to show every binding, leaving a margin
+// around the overlay.
+func (h *helpOverlay) SizeHint() image.Point {
+	width := h.keyWidth + 4
+	for _, l := range h.lines {
+		if w := h.keyWidth + stringWidth(l.desc) + 7; w > width {
+			width = w
+		}
+	}
+	size := image.Pt(width, len(h.lines)+3)
+	if max := h.ui.size.X - 4; size.X > max {
+		size.X = max
+	}
+	if max := h.ui.size.Y - 2; size.Y > max {
+		size.Y = max
+	}
+	return size
+}
+
+// MinSizeHint returns the minimum size of the overlay.
+func (h *helpOverlay) MinSizeHint() image.Point {
+	return image.Pt(20, 4)
+}
+
+func (h *helpOverlay) visibleRows() int {
+	return h.GetInner().Dy() - 1
+}
+
+// Draw draws the visible bindings, the scroll indicators and the search line.
+func (h *helpOverlay) Draw() {
+	h.Lock()
+	defer h.Unlock()
+
+	p := h.GetPainter()
+	if p == nil {
+		return
+	}
+	p.Fill(Cell{' ', Theme.Help.Text}, h.GetOuter())
+	h.Block.draw()
+
+	inner := h.GetInnerRealPos()
+	for i := 0; i < h.visibleRows() && h.top+i < len(h.lines); i++ {
+		l := h.lines[h.top+i]
+		y := inner.Min.Y + i
+		if l.heading != "" {
+			p.DrawText(inner.Min.X, y, TrimString(l.heading, inner.Dx()), &Theme.Help.Scope)
+			continue
+		}
+		p.DrawText(inner.Min.X+2, y, TrimString(l.keys, inner.Dx()-2), &Theme.Help.Key)
+		descX := inner.Min.X + h.keyWidth + 4
+		p.DrawText(descX, y, TrimString(l.desc, inner.Max.X-descX), &Theme.Help.Text)
+	}
+
+	if h.top > 0 {
+		p.DrawRune(inner.Max.X-1, inner.Min.Y, UP_ARROW, &Theme.Help.Text)
+	}
+	if h.top+h.visibleRows() < len(h.lines) {
+		p.DrawRune(inner.Max.X-1, inner.Max.Y-2, DOWN_ARROW, &Theme.Help.Text)
+	}
+
+	y := inner.Max.Y - 1
+	if h.searching || h.search.Text() != "" {
+		p.DrawRune(inner.Min.X, y, '/', &Theme.Help.Key)
+		h.search.Draw()
+	} else {
+		p.DrawText(inner.Min.X, y, TrimString("/ search  "+h.closeKeys()+" close", inner.Dx()), &Theme.Help.Scope)
+	}
+}
+
+// closeKeys returns the keys closing the overlay, as shown in its footer:
+// the help keys, or Esc if there are none.
+func (h *helpOverlay) closeKeys() string {
+	if len(h.ui.helpKeys) == 0 {
+		return KeyEsc
+	}
+	return strings.Join(h.ui.helpKeys, " ")
+}
+
+// DoEvent scrolls the bindings, edits the search query or closes the
+// overlay with Esc or a help key.
+func (h *helpOverlay) DoEvent(ev Event) bool {
+	switch ev.Type {
+	case KeyboardEvent:
+		if h.searching {
+			switch ev.ID {
+			case KeyEnter:
+				h.setSearching(false)
+			case KeyEsc:
+				h.setSearching(false)
+				h.search.SetText("")
+				h.filter()
+			default:
+				h.search.DoEvent(ev)
+			}
+			h.rePaint(h)
+			return true
+		}
+		if ev.ID == KeyEsc || h.ui.isHelpKey(ev) {
+			h.ui.hideOverlay(h)
+			return true
+		}
+		switch ev.ID {
+		case "/":
+			h.setSearching(true)
+		case KeyArrowUp, "k":
+			h.scroll(-1)
+		case KeyArrowDown, "j":
+			h.scroll(1)
+		case KeyPgup:
+			h.scroll(-h.visibleRows())
+		case KeyPgdn, KeySpace:
+			h.scroll(h.visibleRows())
+		case KeyHome:
+			h.scroll(-len(h.lines))
+		case KeyEnd:
+			h.scroll(len(h.lines))
+		}
+		h.rePaint(h)
+		return true
+	case MouseEvent:
+		switch ev.ID {
+		case "<MouseWheelUp>":
+			h.scroll(-1)
+		case "<MouseWheelDown>":
+			h.scroll(1)
+		}
+		h.rePaint(h)
+		return true
+	}
+	return false
+}
+
+func (h *helpOverlay) setSearching(searching bool) {
+	h.searching = searching
+	h.search.SetFocused(searching)
+}
+
+// scroll moves the first visible line by d, keeping the view inside the
+// lines.
+func (h *helpOverlay) scroll(d int) {
+	h.top += d
+	if max := len(h.lines) - h.visibleRows(); h.top > max {
+		h.top = max
+	}
+	if h.top < 0 {
+		h.top = 0
+	}
+}
+
+// toggleHelp shows the keybinding help, or hides it if it is the topmost
+// overlay.
+func (ui *tcellUI) toggleHelp() {
+	if n := len(ui.overlays); n > 0 {
+		if h, ok := ui.overlays[n-1].(*helpOverlay); ok {
+			ui.hideOverlay(h)
+			return
+		}
+	}
+	ui.showOverlay(newHelpOverlay(ui))
+}
+
+// SetHelpKeys sets the key sequences that toggle the keybinding help. Keys
+// that type a character only open the help when no widget handled them.
+func (ui *tcellUI) SetHelpKeys(keys ...string) {
+	ui.helpKeys = keys
+}
+
+func (ui *tcellUI) isHelpKey(ev Event) bool {
+	for _, k := range ui.helpKeys {
+		if k == ev.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// History is a list of the values submitted in an Input, oldest first. It can
+// be shared by several Inputs.
+type History struct {
+	entries []string
+	max     int
+	// path is the file the entries are saved to, if any.
+	path string
+}
+
+// NewHistory returns an empty History that keeps the last max entries, or
+// all of them if max is zero.
+func NewHistory(max int) *History {
+	return &History{max: max}
+}
+
+// LoadHistory returns a History with the entries of the file at path, one
+// per line. Added entries are saved back to the file, which is created if it
+// doesn't exist.
+func LoadHistory(path string, max int) (*History, error) {
+	h := &History{max: max, path: path}
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	h.trim()
+	return h, nil
+}
+
+// Entries returns the entries of the History, oldest first.
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Add adds an entry to the end of the History, unless it is empty or the
+// same as the last entry. The History is saved if it was loaded from a file.
+func (h *History) Add(entry string) error {
+	if entry == "" || len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry {
+		return nil
+	}
+	h.entries = append(h.entries, entry)
+	h.trim()
+	if h.path == "" {
+		return nil
+	}
+	return ioutil.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), 0600)
+}
+
+// trim drops the oldest entries beyond the maximum.
+func (h *History) trim() {
+	if h.max > 0 && len(h.entries) > h.max {
+		h.entries = append([]string(nil), h.entries[len(h.entries)-h.max:]...)
+	}
+}
+
+// search returns the index of the last entry before from containing query,
+// or -1.
+func (h *History) search(query string, from int) int {
+	for i := MinInt(from, len(h.entries)) - 1; i >= 0; i-- {
+		if strings.Contains(h.entries[i], query) {
+			return i
+		}
+	}
+	return -1
+}
+
+// historySearch is the state of a reverse search through the History of an
+// Input.
+type historySearch struct {
+	query []rune
+	// match is the entry found, or -1.
+	match   int
+	failing bool
+	// orig is the text before the search, restored when it is cancelled.
+	orig inputState
+}
+
+// submit adds the text to the History and runs the submit handler, unless
+// the text is invalid.
+func (e *Input) submit() {
+	if e.validate() != nil {
+		return
+	}
+	if e.history != nil {
+		if err := e.history.Add(e.Text()); err != nil {
+			logger.Printf("termuix: saving history: %v", err)
+		}
+		e.histPos = -1
+	}
+	if e.onSubmit != nil {
+		e.onSubmit(e)
+	}
+}
+
+// recall replaces the text with the History entry n steps from the one shown.
+// Going past the last entry brings back the text being typed. It reports
+// whether there was such an entry.
+func (e *Input) recall(n int) bool {
+	if e.history == nil {
+		return false
+	}
+	entries := e.history.Entries()
+	pos := e.histPos
+	if pos < 0 {
+		pos = len(entries)
+	}
+	pos += n
+	if pos < 0 || pos > len(entries) {
+		return false
+	}
+	if e.histPos < 0 {
+		e.draft = e.Text()
+	}
+	text := e.draft
+	e.histPos = -1
+	if pos < len(entries) {
+		text = entries[pos]
+		e.histPos = pos
+	}
+	e.edit(editOther, func() { e.text.Set([]rune(text)) })
+	return true
+}
+
+// startSearch starts a reverse search through the History.
+func (e *Input) startSearch() {
+	e.closeSuggestions()
+	e.search = &historySearch{match: -1, orig: e.snapshot()}
+}
+
+// findMatch shows the last entry before from containing the query.
+func (e *Input) findMatch(from int) {
+	s := e.search
+	i := e.history.search(string(s.query), from)
+	s.failing = i < 0
+	if s.failing {
+		return
+	}
+	s.match = i
+	entry := e.history.Entries()[i]
+	pos := utf8.RuneCountInString(entry[:strings.LastIndex(entry, string(s.query))])
+	e.text.SetWithIdx(pos, []rune(entry))
+}
+
+// doSearchKey handles a key during a search. Keys that don't edit the query
+// end the search, keeping the match, and are left unhandled.
+func (e *Input) doSearchKey(ev Event) bool {
+	s := e.search
+	from := len(e.history.Entries())
+	if s.match >= 0 {
+		from = s.match + 1
+	}
+	switch {
+	case isCharKey(ev.ID):
+		s.query = append(s.query, []rune(ev.ID)...)
+		e.findMatch(from)
+	case ev.ID == KeySpace:
+		s.query = append(s.query, ' ')
+		e.findMatch(from)
+	case ev.ID == KeyBackspace || ev.ID == KeyBackspace2:
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+		}
+		e.findMatch(len(e.history.Entries()))
+	case ev.ID == KeyCtrlR:
+		if s.match >= 0 {
+			from = s.match
+		}
+		e.findMatch(from)
+	case ev.ID == KeyEsc || ev.ID == KeyCtrlG:
+		e.text.SetWithIdx(s.orig.pos, s.orig.text)
+		e.search = nil
+	default:
+		e.endSearch()
+		return false
+	}
+	return true
+}
+
+// endSearch keeps the text found by the search as a single edit.
+func (e *Input) endSearch() {
+	found := e.snapshot()
+	e.text.SetWithIdx(e.search.orig.pos, e.search.orig.text)
+	e.search = nil
+	e.edit(editOther, func() { e.text.SetWithIdx(found.pos, found.text) })
+}
+
+// searchPrompt returns the text shown before the match during a search.
+func (e *Input) searchPrompt() string {
+	prompt := "(reverse-i-search)`" + string(e.search.query) + "': "
+	if e.search.failing {
+		prompt = "(failed " + prompt[1:]
+	}
+	return prompt
+}
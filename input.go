@@ -5,8 +5,9 @@
 package termuix
 
 import (
-	tb "github.com/nsf/termbox-go"
 	"image"
+	"sync"
+	"unicode"
 )
 
 // EchoMode is used to determine the visibility of Input text.
@@ -26,6 +27,12 @@ const (
 
 // Input is a one-line text editor. It lets the user supply the application
 // with text, e.g., to input user and password information.
+//
+// Editing follows readline: text can be selected with the shifted arrow keys
+// or the mouse, killed text is kept in a kill ring shared by all Inputs, and
+// edits can be undone and redone. With a History, submitted values can be
+// recalled and searched, and with a Completer, suggestions are shown below
+// the Input as the user types.
 type Input struct {
 	//WidgetBase
 	Block
@@ -36,12 +43,117 @@ type Input struct {
 
 	echoMode EchoMode
 	offset   int
+	// reserved is the number of columns kept free right of the text, for
+	// widgets built on Input that draw there.
+	reserved int
+
+	// anchor is the end of the selection opposite the cursor, or -1 when
+	// nothing is selected.
+	anchor   int
+	dragging bool
+
+	undo, redo []inputState
+	lastEdit   editKind
+
+	// yank is how far back in the kill ring the last yanked text is, and
+	// yankLen its length, so that yanking again can replace it.
+	yank, yankLen int
+
+	history *History
+	// histPos is the History entry shown, or -1 for the text being typed,
+	// which is kept in draft while browsing the History.
+	histPos int
+	draft   string
+	search  *historySearch
+
+	completer   Completer
+	suggestions popupList
+	// suggestFrom is where an accepted suggestion is inserted.
+	suggestFrom int
+
+	validator Validator
+	mask      Mask
+	// err is the error of the last validation.
+	err error
+}
+
+// inputState is a snapshot of the text and cursor of an Input.
+type inputState struct {
+	text []rune
+	pos  int
+}
+
+// editKind groups consecutive edits of the same kind into one undo step.
+type editKind int
+
+const (
+	editNone editKind = iota
+	editInsert
+	editDelete
+	editKill
+	editYank
+	editOther
+)
+
+// maxUndo is the number of undo steps kept by an Input.
+const maxUndo = 100
+
+// killRingSize is the number of killed texts kept in the kill ring.
+const killRingSize = 32
+
+// killRing holds killed text, the most recent last. The Inputs share one,
+// and they may be used from different goroutines, so it is guarded.
+type killRing struct {
+	mu    sync.Mutex
+	texts [][]rune
+}
+
+// kills is the kill ring shared by the Inputs.
+var kills killRing
+
+// add adds text to the ring. With join set it is added to the most recent
+// text instead, in front of it if backward is set.
+func (r *killRing) add(text []rune, join, backward bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := len(r.texts)
+	switch {
+	case !join || n == 0:
+		r.texts = append(r.texts, text)
+		if len(r.texts) > killRingSize {
+			r.texts = r.texts[1:]
+		}
+	case backward:
+		r.texts[n-1] = append(text, r.texts[n-1]...)
+	default:
+		r.texts[n-1] = append(r.texts[n-1], text...)
+	}
+}
+
+// len returns the number of texts in the ring.
+func (r *killRing) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.texts)
+}
+
+// get returns a copy of the text i steps back from the most recent one, or
+// nil if there is none.
+func (r *killRing) get(i int) []rune {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i < 0 || i >= len(r.texts) {
+		return nil
+	}
+	return append([]rune(nil), r.texts[len(r.texts)-1-i]...)
 }
 
 // NewInput returns a new Input.
 func NewInput() *Input {
 	input := &Input{
-		Block: *NewBlock(),
+		Block:   *NewBlock(),
+		anchor:  -1,
+		histPos: -1,
 	}
 	input.sizePolicyY = Minimum
 	input.SetFocused(true)
@@ -60,22 +172,71 @@ func (e *Input) draw() {
 	if p == nil {
 		return
 	}
-	text := e.visibleText()
-	p.SetString(
-		text,
-		NewStyle(ColorWhite),
-		e.GetInnerRealPos().Min,
-	)
+	e.drawError(p)
+	inner := e.GetInnerRealPos()
+	p.PushClip(inner)
+	defer p.PopClip()
+
+	x := inner.Min.X
+	if e.search != nil {
+		prompt := e.searchPrompt()
+		p.SetString(prompt, Theme.Input.Search, inner.Min)
+		x += stringWidth(prompt)
+		e.offset = 0
+	} else {
+		e.scrollToCursor()
+	}
+	textX := x
+	from, to, selected := e.selection()
+	if e.echoMode != EchoModeNoEcho {
+		for i, c := range e.text.Runes()[e.offset:] {
+			if x >= inner.Max.X-e.reserved {
+				break
+			}
+			if e.echoMode == EchoModePassword {
+				c = '*'
+			}
+			style := Theme.Input.Text
+			if e.err != nil {
+				style = Theme.Input.Invalid
+			}
+			if selected && e.offset+i >= from && e.offset+i < to {
+				style = Theme.Input.Selection
+			}
+			p.SetCell(NewCell(c, style), image.Pt(x, inner.Min.Y))
+			x += runeWidth(c)
+		}
+	}
 	if e.IsFocused() {
 		var off int
 		if e.echoMode != EchoModeNoEcho {
-			off = e.text.CursorPos().X - e.offset
+			off = e.columnOf(e.text.Pos())
+		}
+		p.DrawCursor(textX+off, inner.Min.Y)
+		if len(e.suggestions.items) > 0 {
+			p.DrawPopup(func() {
+				e.Lock()
+				defer e.Unlock()
+				e.drawSuggestions(p)
+			})
 		}
-		inner := e.GetInnerRealPos()
-		tb.SetCursor(inner.Min.X+off, inner.Min.Y)
 	}
 }
 
+// drawError draws the validation error on the bottom border.
+func (e *Input) drawError(p *Painter) {
+	if e.err == nil || !e.Border || !e.BorderBottom {
+		return
+	}
+	min := e.GetParentMin()
+	x := min.X + e.MarginLeft + e.X + 1
+	y := min.Y + e.MarginTop + e.Y + e.Height - e.MarginBottom - 1
+	max := min.X + e.Width - e.MarginRight - 1
+	p.PushClip(image.Rect(x, y, max, y+1))
+	p.SetString(e.err.Error(), Theme.Input.Error, image.Pt(x, y))
+	p.PopClip()
+}
+
 func (e *Input) Draw() {
 	e.Lock()
 	defer e.Unlock()
@@ -84,12 +245,12 @@ func (e *Input) Draw() {
 
 // MinSizeHint returns the minimum size hint for the layout.
 func (s *Input) MinSizeHint() image.Point {
-	return image.Point{10, 1}
+	return image.Point{10, 1}.Add(s.FrameSize())
 }
 
 // SizeHint returns the recommended size hint for the Input.
 func (e *Input) SizeHint() image.Point {
-	return image.Point{10, 1}
+	return image.Point{10, 1}.Add(e.FrameSize())
 }
 
 func (s *Input) DoEvent(ev Event) bool {
@@ -97,6 +258,7 @@ func (s *Input) DoEvent(ev Event) bool {
 	case KeyboardEvent:
 		return s.DoKeyEvent(ev)
 	case MouseEvent:
+		return s.doMouseEvent(ev)
 	case ResizeEvent:
 	default:
 
@@ -109,67 +271,135 @@ func (e *Input) DoKeyEvent(ev Event) bool {
 	if !e.IsFocused() {
 		return false
 	}
-	screenWidth := e.GetInner().Size().X
-	e.text.SetMaxWidth(screenWidth)
-	if !isCharKey(ev.ID) {
-		switch ev.ID {
-		case KeyEnter:
-			if e.onSubmit != nil {
-				e.onSubmit(e)
-			}
-		case KeyBackspace:
-			fallthrough
-		case KeyBackspace2:
-			e.text.Backspace()
-			if e.offset > 0 && !e.isTextRemaining() {
-				e.offset--
-			}
-			if e.onTextChange != nil {
-				e.onTextChange(e)
-			}
-		case KeyDelete, KeyCtrlD:
-			e.text.Delete()
-			if e.onTextChange != nil {
-				e.onTextChange(e)
-			}
-		case KeyArrowLeft, KeyCtrlB:
-			e.text.MoveBackward()
-			if e.offset > 0 {
-				e.offset--
-			}
-		case KeyArrowRight, KeyCtrlF:
-			e.text.MoveForward()
-
-			isCursorTooFar := e.text.CursorPos().X >= screenWidth
-			isTextLeft := (e.text.Width() - e.offset) > (screenWidth - 1)
-
-			if isCursorTooFar && isTextLeft {
-				e.offset++
-			}
-		case KeyHome, KeyCtrlA:
-			e.text.MoveToLineStart()
-			e.offset = 0
-		case KeyEnd, KeyCtrlE:
-			e.text.MoveToLineEnd()
-			e.ensureCursorIsVisible()
-		case KeyCtrlK:
-			e.text.Kill()
+	if e.search != nil && e.doSearchKey(ev) ||
+		len(e.suggestions.items) > 0 && e.doSuggestionKey(ev) {
+		e.rePaint(e)
+		return true
+	}
+	if isCharKey(ev.ID) {
+		e.insert([]rune(ev.ID))
+		e.complete()
+		e.rePaint(e)
+		return true
+	}
+	if ev.ID == KeyTab {
+		if !e.completeWord() {
+			return false
 		}
 		e.rePaint(e)
 		return true
 	}
-	idCode := []rune(ev.ID)[0]
-	e.text.WriteRune(rune(idCode))
-	if e.text.CursorPos().X >= screenWidth {
-		e.offset++
+	typing := false
+	switch ev.ID {
+	case KeyEnter:
+		e.submit()
+	case KeySpace:
+		e.insert([]rune{' '})
+		typing = true
+	case KeyBackspace, KeyBackspace2:
+		if !e.deleteSelection() {
+			e.edit(editDelete, e.text.Backspace)
+		}
+		typing = true
+	case KeyDelete, KeyCtrlD:
+		if !e.deleteSelection() {
+			e.edit(editDelete, e.text.Delete)
+		}
+		typing = true
+	case KeyArrowUp:
+		if !e.recall(-1) {
+			return false
+		}
+	case KeyArrowDown:
+		if !e.recall(1) {
+			return false
+		}
+	case KeyCtrlR:
+		if e.history == nil {
+			return false
+		}
+		e.startSearch()
+	case KeyArrowLeft, KeyCtrlB:
+		e.move(e.text.MoveBackward)
+	case KeyArrowRight, KeyCtrlF:
+		e.move(e.text.MoveForward)
+	case "<M-b>":
+		e.move(e.text.MoveBackwardWord)
+	case "<M-f>":
+		e.move(e.text.MoveForwardWord)
+	case KeyHome, KeyCtrlA:
+		e.move(e.text.MoveToLineStart)
+	case KeyEnd, KeyCtrlE:
+		e.move(e.text.MoveToLineEnd)
+	case KeyShiftArrowLeft:
+		e.extendSelection(e.text.MoveBackward)
+	case KeyShiftArrowRight:
+		e.extendSelection(e.text.MoveForward)
+	case KeyShiftHome:
+		e.extendSelection(e.text.MoveToLineStart)
+	case KeyShiftEnd:
+		e.extendSelection(e.text.MoveToLineEnd)
+	case KeyCtrlK:
+		e.kill(e.text.Pos(), e.text.Len(), false)
+	case KeyCtrlU:
+		e.kill(0, e.text.Pos(), true)
+	case KeyCtrlW:
+		if from, to, ok := e.selection(); ok {
+			e.kill(from, to, false)
+		} else {
+			e.kill(e.spaceStart(), e.text.Pos(), true)
+		}
+	case "<M-d>":
+		e.kill(e.text.Pos(), e.text.wordEnd(e.text.Pos()), false)
+	case "<M-<Backspace>>":
+		e.kill(e.text.wordStart(e.text.Pos()), e.text.Pos(), true)
+	case KeyCtrlY:
+		e.yankText()
+	case "<M-y>":
+		e.yankPop()
+	case KeyCtrlZ, KeyCtrl7:
+		e.Undo()
+	case "<M-z>":
+		e.Redo()
+	default:
+		return false
 	}
-	if e.onTextChange != nil {
-		e.onTextChange(e)
+	if typing {
+		e.complete()
+	} else {
+		e.closeSuggestions()
 	}
 	e.rePaint(e)
 	return true
 }
 
+// Keybindings returns the keys handled by the Input.
+func (e *Input) Keybindings() []KeyHelp {
+	return []KeyHelp{
+		{[]string{KeyEnter}, "Submit the text"},
+		{[]string{KeyTab}, "Complete the word before the cursor"},
+		{[]string{KeyArrowUp, KeyArrowDown}, "Recall the previous or next value"},
+		{[]string{KeyCtrlR}, "Search the previous values"},
+		{[]string{KeyBackspace2}, "Delete the character before the cursor"},
+		{[]string{KeyDelete, KeyCtrlD}, "Delete the character under the cursor"},
+		{[]string{KeyArrowLeft, KeyCtrlB}, "Move back one character"},
+		{[]string{KeyArrowRight, KeyCtrlF}, "Move forward one character"},
+		{[]string{"<M-b>"}, "Move back one word"},
+		{[]string{"<M-f>"}, "Move forward one word"},
+		{[]string{KeyHome, KeyCtrlA}, "Move to the start of the line"},
+		{[]string{KeyEnd, KeyCtrlE}, "Move to the end of the line"},
+		{[]string{KeyShiftArrowLeft, KeyShiftArrowRight, KeyShiftHome, KeyShiftEnd}, "Extend the selection"},
+		{[]string{KeyCtrlK}, "Kill to the end of the line"},
+		{[]string{KeyCtrlU}, "Kill to the start of the line"},
+		{[]string{KeyCtrlW}, "Kill the selection or the word before the cursor"},
+		{[]string{"<M-d>"}, "Kill the word after the cursor"},
+		{[]string{KeyCtrlY}, "Yank the last killed text"},
+		{[]string{"<M-y>"}, "Replace the yanked text with the text killed before it"},
+		{[]string{KeyCtrlZ}, "Undo"},
+		{[]string{"<M-z>"}, "Redo"},
+	}
+}
+
 // OnChanged sets a function to be run whenever the content of the Input has
 // been changed.
 func (e *Input) OnChanged(fn func(Input *Input)) {
@@ -182,6 +412,55 @@ func (e *Input) OnSubmit(fn func(Input *Input)) {
 	e.onSubmit = fn
 }
 
+// SetHistory sets the History the Input adds submitted values to and recalls
+// them from.
+func (e *Input) SetHistory(h *History) {
+	e.history = h
+	e.histPos = -1
+}
+
+// SetCompleter sets the Completer suggesting completions for the text.
+func (e *Input) SetCompleter(c Completer) {
+	e.completer = c
+	e.closeSuggestions()
+}
+
+// SetValidator sets the Validator checking the text whenever it changes and
+// before it is submitted. Invalid text isn't submitted.
+func (e *Input) SetValidator(v Validator) {
+	e.validator = v
+	e.err = nil
+}
+
+// SetMask sets the Mask restricting what can be typed into the Input.
+func (e *Input) SetMask(m Mask) {
+	e.mask = m
+}
+
+// Validate checks the text with the Validator and returns the error, which
+// is also shown below the text.
+func (e *Input) Validate() error {
+	e.Lock()
+	defer e.Unlock()
+	err := e.validate()
+	e.rePaint(e)
+	return err
+}
+
+func (e *Input) validate() error {
+	e.err = nil
+	if e.validator != nil {
+		e.err = e.validator(e.text.String())
+	}
+	return e.err
+}
+
+// Err returns the error of the last validation, or nil if the text was
+// valid.
+func (e *Input) Err() error {
+	return e.err
+}
+
 // SetEchoMode sets the echo mode of the Input.
 func (e *Input) SetEchoMode(m EchoMode) {
 	e.echoMode = m
@@ -190,46 +469,281 @@ func (e *Input) SetEchoMode(m EchoMode) {
 // SetText sets the text content of the Input.
 func (e *Input) setText(text string) {
 	e.text.Set([]rune(text))
-	// TODO: Enable when RuneBuf supports cursor movement for CJK.
-	// e.ensureCursorIsVisible()
 	e.offset = 0
+	e.anchor = -1
+	e.lastEdit = editNone
+	e.err = nil
 }
 
-// SetText sets the text content of the Input.
+// SetText sets the text content of the Input and clears the undo history.
 func (e *Input) SetText(text string) {
 	e.Lock()
 	defer e.Unlock()
 	e.setText(text)
+	e.undo, e.redo = nil, nil
 	e.rePaint(e)
 }
 
-func (e *Input) ensureCursorIsVisible() {
-	left := e.text.Width() - (e.GetInner().Size().X - 1)
-	if left >= 0 {
-		e.offset = left
-	} else {
-		e.offset = 0
-	}
-}
-
 // Text returns the text content of the Input.
 func (e *Input) Text() string {
 	return e.text.String()
 }
 
-func (e *Input) visibleText() string {
-	text := e.text
-	if text.Len() == 0 {
+// SelectedText returns the selected text, or an empty string if nothing is
+// selected.
+func (e *Input) SelectedText() string {
+	from, to, ok := e.selection()
+	if !ok {
 		return ""
 	}
-	windowStart := e.offset
-	windowEnd := e.GetInner().Size().X + windowStart
-	if windowEnd > text.Len() {
-		windowEnd = text.Len()
+	return string(e.text.Runes()[from:to])
+}
+
+// Undo reverts the last group of edits.
+func (e *Input) Undo() {
+	if len(e.undo) == 0 {
+		return
 	}
-	return string(text.Runes()[windowStart:windowEnd])
+	e.redo = append(e.redo, e.snapshot())
+	e.restore(e.undo[len(e.undo)-1])
+	e.undo = e.undo[:len(e.undo)-1]
 }
 
-func (e *Input) isTextRemaining() bool {
-	return e.text.Width()-e.offset > e.GetInner().Size().X
+// Redo reapplies the last group of edits reverted by Undo.
+func (e *Input) Redo() {
+	if len(e.redo) == 0 {
+		return
+	}
+	e.undo = append(e.undo, e.snapshot())
+	e.restore(e.redo[len(e.redo)-1])
+	e.redo = e.redo[:len(e.redo)-1]
+}
+
+func (e *Input) snapshot() inputState {
+	return inputState{append([]rune(nil), e.text.Runes()...), e.text.Pos()}
+}
+
+func (e *Input) restore(s inputState) {
+	e.text.SetWithIdx(s.pos, append([]rune(nil), s.text...))
+	e.anchor = -1
+	e.lastEdit = editNone
+	e.changed()
+}
+
+// edit applies fn to the text. Consecutive edits of the same kind are undone
+// together, except for yanks.
+func (e *Input) edit(kind editKind, fn func()) {
+	before := e.snapshot()
+	fn()
+	e.anchor = -1
+	if e.text.String() == string(before.text) {
+		return
+	}
+	if e.mask != nil && !e.mask(e.text.String()) {
+		e.text.SetWithIdx(before.pos, before.text)
+		return
+	}
+	if kind != e.lastEdit || kind == editYank || kind == editOther {
+		e.undo = append(e.undo, before)
+		if len(e.undo) > maxUndo {
+			e.undo = e.undo[1:]
+		}
+	}
+	e.lastEdit = kind
+	e.redo = nil
+	e.changed()
+}
+
+func (e *Input) changed() {
+	e.validate()
+	if e.onTextChange != nil {
+		e.onTextChange(e)
+	}
+}
+
+// insert replaces the selection, if any, with runes.
+func (e *Input) insert(runes []rune) {
+	e.edit(editInsert, func() {
+		if from, to, ok := e.selection(); ok {
+			e.text.DeleteRange(from, to)
+		}
+		e.text.WriteRunes(runes)
+	})
+}
+
+// deleteSelection deletes the selected text. It reports whether there was
+// any.
+func (e *Input) deleteSelection() bool {
+	from, to, ok := e.selection()
+	if !ok {
+		return false
+	}
+	e.edit(editOther, func() { e.text.DeleteRange(from, to) })
+	return true
+}
+
+// move moves the cursor with fn and clears the selection.
+func (e *Input) move(fn func()) {
+	fn()
+	e.anchor = -1
+	e.lastEdit = editNone
+}
+
+// extendSelection moves the cursor with fn, keeping the other end of the
+// selection in place.
+func (e *Input) extendSelection(fn func()) {
+	if e.anchor < 0 {
+		e.anchor = e.text.Pos()
+	}
+	fn()
+	e.lastEdit = editNone
+}
+
+// selection returns the bounds of the selected text, and whether any text is
+// selected.
+func (e *Input) selection() (from, to int, ok bool) {
+	if e.anchor < 0 || e.anchor == e.text.Pos() {
+		return 0, 0, false
+	}
+	return MinInt(e.anchor, e.text.Pos()), MaxInt(e.anchor, e.text.Pos()), true
+}
+
+// spaceStart returns the start of the whitespace-delimited word before the
+// cursor.
+func (e *Input) spaceStart() int {
+	runes := e.text.Runes()
+	i := e.text.Pos()
+	for i > 0 && unicode.IsSpace(runes[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(runes[i-1]) {
+		i--
+	}
+	return i
+}
+
+// kill deletes the text between from and to and adds it to the kill ring.
+// Consecutive kills are added as one, the text killed backward in front. The
+// text of an Input that hides it is deleted without being kept, so that it
+// can't be yanked into another Input.
+func (e *Input) kill(from, to int, backward bool) {
+	if from >= to {
+		return
+	}
+	if e.echoMode != EchoModeNormal {
+		e.edit(editDelete, func() { e.text.DeleteRange(from, to) })
+		return
+	}
+	var killed []rune
+	join := e.lastEdit == editKill
+	e.edit(editKill, func() { killed = e.text.DeleteRange(from, to) })
+	kills.add(killed, join, backward)
+}
+
+// yankText inserts the last killed text at the cursor.
+func (e *Input) yankText() {
+	if kills.len() == 0 {
+		return
+	}
+	e.yank = 0
+	e.insertYank()
+}
+
+// yankPop replaces the text just yanked with the text killed before it.
+func (e *Input) yankPop() {
+	n := kills.len()
+	if e.lastEdit != editYank || n == 0 {
+		return
+	}
+	e.yank = (e.yank + 1) % n
+	pos := e.text.Pos()
+	// The yank is replaced within the same undo step.
+	steps := len(e.undo)
+	e.text.DeleteRange(pos-e.yankLen, pos)
+	e.insertYank()
+	e.undo = e.undo[:MinInt(steps, len(e.undo))]
+	e.lastEdit = editYank
+}
+
+func (e *Input) insertYank() {
+	text := kills.get(e.yank)
+	e.yankLen = len(text)
+	e.edit(editYank, func() {
+		if from, to, ok := e.selection(); ok {
+			e.text.DeleteRange(from, to)
+		}
+		e.text.WriteRunes(text)
+	})
+}
+
+// columnOf returns the column of index i relative to the scrolled view.
+func (e *Input) columnOf(i int) int {
+	if i < e.offset {
+		return 0
+	}
+	return stringWidth(string(e.text.Runes()[e.offset:i]))
+}
+
+// indexAt returns the index of the character at column x of the view.
+func (e *Input) indexAt(x int) int {
+	runes := e.text.Runes()
+	var w int
+	for i := e.offset; i < len(runes); i++ {
+		rw := runeWidth(runes[i])
+		if w+rw > x {
+			return i
+		}
+		w += rw
+	}
+	return len(runes)
+}
+
+// scrollToCursor scrolls as little as needed to show the cursor, and shows
+// hidden text at the start rather than empty space at the end.
+func (e *Input) scrollToCursor() {
+	width := e.GetInner().Dx() - e.reserved
+	runes := e.text.Runes()
+	e.offset = MinInt(e.offset, e.text.Pos())
+	for e.offset < e.text.Pos() && e.columnOf(e.text.Pos()) >= width {
+		e.offset++
+	}
+	for e.offset > 0 && stringWidth(string(runes[e.offset-1:]))+1 <= width {
+		e.offset--
+	}
+}
+
+// doMouseEvent places the cursor on a click, and selects text while the
+// mouse is dragged.
+func (e *Input) doMouseEvent(ev Event) bool {
+	switch ev.ID {
+	case "<MouseLeft>":
+		m := ev.Payload.(Mouse)
+		inner := e.GetInnerRealPos()
+		pt := image.Pt(m.X, m.Y)
+		if i := e.suggestions.itemAt(pt); !m.Drag && i >= 0 {
+			e.acceptSuggestion(i)
+		} else if !m.Drag {
+			if !pt.In(realOuter(e)) {
+				return false
+			}
+			e.text.MoveTo(e.indexAt(pt.X - inner.Min.X))
+			e.anchor = e.text.Pos()
+			e.dragging = true
+		} else if e.dragging {
+			e.text.MoveTo(e.indexAt(MaxInt(pt.X-inner.Min.X, 0)))
+		} else {
+			return false
+		}
+		e.lastEdit = editNone
+		e.closeSuggestions()
+	case "<MouseRelease>":
+		if !e.dragging {
+			return false
+		}
+		e.dragging = false
+	default:
+		return false
+	}
+	e.rePaint(e)
+	return true
 }
@@ -0,0 +1,344 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"fmt"
+	"image"
+	"reflect"
+)
+
+// DefaultInspectorKey is the key sequence that toggles the layout inspector
+// unless SetInspectorKey is called.
+var DefaultInspectorKey = KeyF12
+
+// inspectorDetailRows is the number of rows describing the selected widget.
+const inspectorDetailRows = 9
+
+// inspectorRow is a widget in the tree of the layout inspector.
+type inspectorRow struct {
+	w     Widget
+	depth int
+}
+
+// layoutInspector covers the screen and outlines the outer, margin, padding
+// and inner rectangles of every widget. A panel lists the widget tree and
+// describes the layout of the selected widget, which is highlighted.
+type layoutInspector struct {
+	Block
+	ui *tcellUI
+
+	rows     []inspectorRow
+	selected Widget
+	top      int
+	// panel is the area of the tree and details, in screen coordinates.
+	panel image.Rectangle
+}
+
+func newLayoutInspector(ui *tcellUI) *layoutInspector {
+	l := &layoutInspector{
+		Block:    *NewBlock(),
+		ui:       ui,
+		selected: ui.root,
+	}
+	l.Border = false
+	l.collect()
+	return l
+}
+
+// collect rebuilds the rows from the widget tree, keeping the selection if
+// the selected widget is still in the tree.
+func (l *layoutInspector) collect() {
+	l.rows = l.rows[:0]
+	var walk func(w Widget, depth int)
+	walk = func(w Widget, depth int) {
+		l.rows = append(l.rows, inspectorRow{w, depth})
+		for _, c := range w.Children() {
+			walk(c, depth+1)
+		}
+	}
+	if l.ui.root != nil {
+		walk(l.ui.root, 0)
+	}
+	if l.index(l.selected) < 0 && len(l.rows) > 0 {
+		l.selected = l.rows[0].w
+	}
+}
+
+// index returns the row of w, or -1.
+func (l *layoutInspector) index(w Widget) int {
+	for i, r := range l.rows {
+		if r.w == w {
+			return i
+		}
+	}
+	return -1
+}
+
+// Resize covers the whole screen.
+func (l *layoutInspector) Resize(pos image.Point, size image.Point) {
+	l.SetRect(pos.X, pos.Y, size.X, size.Y)
+}
+
+// SizeHint returns the size of the screen.
+func (l *layoutInspector) SizeHint() image.Point {
+	return l.ui.size
+}
+
+// MinSizeHint returns the minimum size of the inspector.
+func (l *layoutInspector) MinSizeHint() image.Point {
+	return image.Pt(20, inspectorDetailRows+3)
+}
+
+// treeRows returns the number of rows of the tree in the panel.
+func (l *layoutInspector) treeRows() int {
+	return MaxInt(0, l.panel.Dy()-inspectorDetailRows-2)
+}
+
+// Draw outlines the widgets on top of the UI and draws the panel.
+func (l *layoutInspector) Draw() {
+	l.Lock()
+	defer l.Unlock()
+
+	p := l.GetPainter()
+	if p == nil {
+		return
+	}
+	l.collect()
+	for _, r := range l.rows {
+		l.outline(p, r.w, false)
+	}
+	if l.selected != nil {
+		l.outline(p, l.selected, true)
+	}
+
+	screen := l.GetOuter()
+	width := MinInt(44, screen.Dx()/2)
+	l.panel = image.Rect(screen.Max.X-width, screen.Min.Y, screen.Max.X, screen.Max.Y)
+	if l.selected != nil {
+		sel := realOuter(l.selected)
+		if (sel.Min.X+sel.Max.X)/2 >= screen.Min.X+screen.Dx()/2 {
+			l.panel = image.Rect(screen.Min.X, screen.Min.Y, screen.Min.X+width, screen.Max.Y)
+		}
+	}
+	l.drawPanel(p)
+}
+
+// inspectorRects returns the outer, margin, padding and inner rectangles of
+// w in screen coordinates.
+func inspectorRects(w Widget) [4]image.Rectangle {
+	outer := realOuter(w)
+	inner := w.GetInnerRealPos()
+	rects := [4]image.Rectangle{outer, outer, inner, inner}
+	if b, ok := w.(blocked); ok {
+		s := b.block()
+		rects[1] = image.Rect(outer.Min.X+s.MarginLeft, outer.Min.Y+s.MarginTop,
+			outer.Max.X-s.MarginRight, outer.Max.Y-s.MarginBottom)
+		rects[2] = image.Rect(inner.Min.X-s.PaddingLeft, inner.Min.Y-s.PaddingTop,
+			inner.Max.X+s.PaddingRight, inner.Max.Y+s.PaddingBottom)
+	}
+	return rects
+}
+
+// outline draws the rectangles of w. The edges of the rectangles of every
+// widget are colored; those of the selected widget are also highlighted.
+func (l *layoutInspector) outline(p *Painter, w Widget, selected bool) {
+	t := Theme.Inspector
+	styles := [4]Style{t.Outer, t.Margin, t.Padding, t.Inner}
+	for i, r := range inspectorRects(w) {
+		st := styles[i]
+		if selected {
+			st.Modifier |= ModifierReverse
+		}
+		l.tint(p, r, st)
+	}
+}
+
+// tint colors the cells on the edges of r. Empty cells get a dashed line so
+// that the edge is visible.
+func (l *layoutInspector) tint(p *Painter, r image.Rectangle, st Style) {
+	r = r.Intersect(l.GetOuter())
+	if r.Empty() {
+		return
+	}
+	set := func(x, y int, line rune) {
+		pt := image.Pt(x, y)
+		c := p.GetCell(pt)
+		if c.Rune == 0 || c.Rune == ' ' {
+			c.Rune = line
+		}
+		c.Style = st
+		p.SetCell(c, pt)
+	}
+	for x := r.Min.X; x < r.Max.X; x++ {
+		set(x, r.Min.Y, HORIZONTAL_DASH)
+		set(x, r.Max.Y-1, HORIZONTAL_DASH)
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		set(r.Min.X, y, VERTICAL_DASH)
+		set(r.Max.X-1, y, VERTICAL_DASH)
+	}
+}
+
+// drawPanel draws the widget tree and the details of the selected widget.
+func (l *layoutInspector) drawPanel(p *Painter) {
+	t := Theme.Inspector
+	r := l.panel
+	p.Fill(Cell{' ', t.Text}, r)
+	x, width := r.Min.X+1, r.Dx()-2
+
+	p.DrawText(x, r.Min.Y, TrimString("Layout inspector", width), &t.Title)
+	sel := l.index(l.selected)
+	rows := l.treeRows()
+	if sel < l.top {
+		l.top = sel
+	}
+	if sel >= l.top+rows {
+		l.top = sel - rows + 1
+	}
+	for i := 0; i < rows && l.top+i < len(l.rows); i++ {
+		row := l.rows[l.top+i]
+		st := t.Text
+		if l.top+i == sel {
+			st = t.Selected
+			p.Fill(Cell{' ', st}, image.Rect(r.Min.X, r.Min.Y+1+i, r.Max.X, r.Min.Y+2+i))
+		}
+		text := fmt.Sprintf("%*s%s", row.depth*2, "", widgetLabel(row.w))
+		p.DrawText(x, r.Min.Y+1+i, TrimString(text, width), &st)
+	}
+
+	y := r.Max.Y - inspectorDetailRows
+	p.Fill(Cell{HORIZONTAL_LINE, t.Text}, image.Rect(r.Min.X, y-1, r.Max.X, y))
+	for i, line := range inspectorDetails(l.selected) {
+		p.DrawText(x, y+i, TrimString(line, width), &t.Text)
+	}
+}
+
+// widgetLabel returns the type of w and its title, if any.
+func widgetLabel(w Widget) string {
+	name := reflect.Indirect(reflect.ValueOf(w)).Type().Name()
+	if b, ok := w.(blocked); ok && b.block().Title != "" {
+		name += fmt.Sprintf(" %q", b.block().Title)
+	}
+	return name
+}
+
+// inspectorDetails describes the layout of w.
+func inspectorDetails(w Widget) []string {
+	if w == nil {
+		return nil
+	}
+	px, py := w.SizePolicy()
+	hint, min := w.SizeHint(), w.MinSizeHint()
+	rects := inspectorRects(w)
+	outer := w.GetOuter()
+	lines := []string{
+		widgetLabel(w),
+		fmt.Sprintf("Policy  %v x %v", px, py),
+		fmt.Sprintf("Hint    %dx%d  min %dx%d", hint.X, hint.Y, min.X, min.Y),
+		fmt.Sprintf("Size    %dx%d at %d,%d", outer.Dx(), outer.Dy(), outer.Min.X, outer.Min.Y),
+		fmt.Sprintf("Outer   %v", rects[0]),
+		fmt.Sprintf("Margin  %v", rects[1]),
+		fmt.Sprintf("Padding %v", rects[2]),
+		fmt.Sprintf("Inner   %v", rects[3]),
+	}
+	if c, ok := w.(interface{ ConstraintViolations() []ConstraintViolation }); ok {
+		if v := c.ConstraintViolations(); len(v) > 0 {
+			lines = append(lines, fmt.Sprintf("Violated %v", v[0]))
+		}
+	}
+	return lines
+}
+
+// DoEvent moves the selection and closes the inspector.
+func (l *layoutInspector) DoEvent(ev Event) bool {
+	switch ev.Type {
+	case KeyboardEvent:
+		sel := l.index(l.selected)
+		switch ev.ID {
+		case KeyEsc, "q", l.ui.inspectorKey:
+			l.ui.hideOverlay(l)
+			return true
+		case KeyArrowUp, "k":
+			l.selectRow(sel - 1)
+		case KeyArrowDown, "j":
+			l.selectRow(sel + 1)
+		case KeyPgup:
+			l.selectRow(sel - l.treeRows())
+		case KeyPgdn:
+			l.selectRow(sel + l.treeRows())
+		case KeyHome:
+			l.selectRow(0)
+		case KeyEnd:
+			l.selectRow(len(l.rows) - 1)
+		case KeyArrowLeft, "h":
+			// Select the parent, the closest row above at a smaller depth.
+			for i := sel - 1; i >= 0; i-- {
+				if l.rows[i].depth < l.rows[sel].depth {
+					l.selectRow(i)
+					break
+				}
+			}
+		case KeyArrowRight, "l":
+			if sel+1 < len(l.rows) && l.rows[sel+1].depth > l.rows[sel].depth {
+				l.selectRow(sel + 1)
+			}
+		}
+		l.rePaint(l)
+		return true
+	case MouseEvent:
+		m := ev.Payload.(Mouse)
+		pt := image.Pt(m.X, m.Y)
+		switch {
+		case ev.ID == "<MouseWheelUp>":
+			l.selectRow(l.index(l.selected) - 1)
+		case ev.ID == "<MouseWheelDown>":
+			l.selectRow(l.index(l.selected) + 1)
+		case ev.ID != "<MouseLeft>":
+		case pt.In(l.panel):
+			if i := pt.Y - l.panel.Min.Y - 1; i >= 0 && i < l.treeRows() {
+				l.selectRow(l.top + i)
+			}
+		default:
+			// Select the deepest widget under the pointer.
+			for i, r := range l.rows {
+				if pt.In(realOuter(r.w)) {
+					l.selectRow(i)
+				}
+			}
+		}
+		l.rePaint(l)
+		return true
+	}
+	return false
+}
+
+func (l *layoutInspector) selectRow(i int) {
+	if i >= len(l.rows) {
+		i = len(l.rows) - 1
+	}
+	if i < 0 {
+		return
+	}
+	l.selected = l.rows[i].w
+}
+
+// toggleInspector shows the layout inspector, or hides it if it is the
+// topmost overlay.
+func (ui *tcellUI) toggleInspector() {
+	if n := len(ui.overlays); n > 0 {
+		if l, ok := ui.overlays[n-1].(*layoutInspector); ok {
+			ui.hideOverlay(l)
+			return
+		}
+	}
+	ui.showOverlay(newLayoutInspector(ui))
+}
+
+// SetInspectorKey sets the key sequence that toggles the layout inspector. An
+// empty sequence disables it.
+func (ui *tcellUI) SetInspectorKey(seq string) {
+	ui.inspectorKey = seq
+}
@@ -9,6 +9,8 @@ import "strings"
 type keybinding struct {
 	sequence string
 	handler  func()
+	// command is the name of the command the binding belongs to, if any.
+	command string
 }
 
 func (b *keybinding) match(ev Event) bool {
@@ -0,0 +1,238 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Keymap maps key sequences, such as "<C-x>" or "<M-f>", to the names of
+// registered commands. Commands named in a keymap are bound to the keys of the
+// keymap instead of the keys they were registered with.
+//
+// A keymap file is a flat table, e.g. in JSON:
+//
+//	{"<C-s>": "Save", "<M-o>": "Open File"}
+type Keymap map[string]string
+
+// keysFor returns the keys the keymap binds to the named command.
+func (km Keymap) keysFor(name string) ([]string, bool) {
+	var keys []string
+	for seq, cmd := range km {
+		if cmd == name {
+			keys = append(keys, seq)
+		}
+	}
+	sort.Strings(keys)
+	return keys, len(keys) > 0
+}
+
+// ParseKeymap parses a keymap in the given format, which is one of "json",
+// "toml" or "yaml".
+func ParseKeymap(data []byte, format string) (Keymap, error) {
+	km := make(Keymap)
+	var err error
+	switch strings.ToLower(format) {
+	case "json":
+		err = json.Unmarshal(data, &km)
+	case "toml":
+		err = toml.Unmarshal(data, &km)
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &km)
+	default:
+		return nil, fmt.Errorf("termuix: unknown keymap format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("termuix: parsing keymap: %v", err)
+	}
+	return km, nil
+}
+
+// ReadKeymap reads a keymap file. The format is taken from the file
+// extension.
+func ReadKeymap(path string) (Keymap, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeymap(data, strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// KeymapConflict is a key sequence bound to more than one action.
+type KeymapConflict struct {
+	Key     string
+	Actions []string
+}
+
+// KeymapError lists the problems that kept a keymap from being applied.
+type KeymapError struct {
+	// Invalid holds the key sequences that are not valid keys.
+	Invalid []string
+	// Unknown holds the action names that are not registered commands.
+	Unknown []string
+	// Conflicts holds the keys of the keymap that are also bound elsewhere.
+	Conflicts []KeymapConflict
+}
+
+func (e *KeymapError) Error() string {
+	var problems []string
+	for _, k := range e.Invalid {
+		problems = append(problems, fmt.Sprintf("invalid key %q", k))
+	}
+	for _, a := range e.Unknown {
+		problems = append(problems, fmt.Sprintf("unknown action %q", a))
+	}
+	for _, c := range e.Conflicts {
+		problems = append(problems, fmt.Sprintf("key %q is bound to %s", c.Key, strings.Join(c.Actions, ", ")))
+	}
+	return "termuix: bad keymap: " + strings.Join(problems, "; ")
+}
+
+// isValidKey reports whether seq is a key sequence that can be received
+// from the terminal.
+func isValidKey(seq string) bool {
+	if isCharKey(seq) {
+		return utf8.RuneCountInString(seq) == 1
+	}
+	if strings.HasPrefix(seq, "<M-") && strings.HasSuffix(seq, ">") {
+		key := seq[3 : len(seq)-1]
+		return !strings.HasPrefix(key, "<M-") && isValidKey(key)
+	}
+	for _, name := range keyboardMap {
+		if strings.EqualFold(name, seq) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkKeymap validates km against the registered commands and the bindings
+// that km leaves in place.
+func (ui *tcellUI) checkKeymap(km Keymap) error {
+	var kerr KeymapError
+
+	// owners maps the folded key sequences to the actions bound to them.
+	owners := make(map[string][]string)
+	bind := func(seq, action string) {
+		k := strings.ToLower(seq)
+		for _, a := range owners[k] {
+			if a == action {
+				return
+			}
+		}
+		owners[k] = append(owners[k], action)
+	}
+
+	for seq, action := range km {
+		if !isValidKey(seq) {
+			kerr.Invalid = append(kerr.Invalid, seq)
+		}
+		if _, ok := ui.commands.get(action); !ok {
+			kerr.Unknown = append(kerr.Unknown, action)
+		}
+		bind(seq, action)
+	}
+
+	for _, seq := range ui.quitKeys {
+		bind(seq, "quit")
+	}
+	for _, seq := range ui.helpKeys {
+		bind(seq, "help")
+	}
+	if ui.paletteKey != "" {
+		bind(ui.paletteKey, "palette")
+	}
+	for _, b := range ui.keybindings {
+		if b.command == "" {
+			bind(b.sequence, "keybinding")
+		}
+	}
+	// Commands the keymap doesn't name go back to their registered keys.
+	for name, keys := range ui.commands.defaults {
+		if km.has(name) {
+			continue
+		}
+		for _, seq := range keys {
+			bind(seq, name)
+		}
+	}
+
+	for seq := range km {
+		if actions := owners[strings.ToLower(seq)]; len(actions) > 1 {
+			kerr.Conflicts = append(kerr.Conflicts, KeymapConflict{Key: seq, Actions: actions})
+		}
+	}
+
+	if len(kerr.Invalid) == 0 && len(kerr.Unknown) == 0 && len(kerr.Conflicts) == 0 {
+		return nil
+	}
+	sort.Strings(kerr.Invalid)
+	sort.Strings(kerr.Unknown)
+	sort.Slice(kerr.Conflicts, func(i, j int) bool {
+		return kerr.Conflicts[i].Key < kerr.Conflicts[j].Key
+	})
+	return &kerr
+}
+
+// has reports whether the keymap binds any key to the named command.
+func (km Keymap) has(name string) bool {
+	for _, cmd := range km {
+		if cmd == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyKeymap rebinds the registered commands according to km. Commands the
+// keymap doesn't name go back to the keys they were registered with. If km
+// has invalid keys, unknown actions or conflicting keys, nothing is changed
+// and a *KeymapError is returned.
+func (ui *tcellUI) ApplyKeymap(km Keymap) error {
+	if err := ui.checkKeymap(km); err != nil {
+		return err
+	}
+	ui.keymap = km
+	for _, c := range ui.commands.list() {
+		cmd := *c
+		cmd.Keys = ui.commands.defaults[cmd.Name]
+		if keys, ok := km.keysFor(cmd.Name); ok {
+			cmd.Keys = keys
+		}
+		ui.bindCommand(cmd)
+	}
+	return nil
+}
+
+// LoadKeymap reads the keymap file at path and applies it. The path is
+// remembered for ReloadKeymap.
+func (ui *tcellUI) LoadKeymap(path string) error {
+	km, err := ReadKeymap(path)
+	if err != nil {
+		return err
+	}
+	if err := ui.ApplyKeymap(km); err != nil {
+		return err
+	}
+	ui.keymapPath = path
+	return nil
+}
+
+// ReloadKeymap reads the last loaded keymap file again and applies it.
+func (ui *tcellUI) ReloadKeymap() error {
+	if ui.keymapPath == "" {
+		return fmt.Errorf("termuix: no keymap loaded")
+	}
+	return ui.LoadKeymap(ui.keymapPath)
+}
@@ -0,0 +1,205 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseKeymap(t *testing.T) {
+	want := Keymap{"<C-s>": "Save", "<M-o>": "Open File"}
+	tests := []struct {
+		format string
+		data   string
+	}{
+		{"json", `{"<C-s>": "Save", "<M-o>": "Open File"}`},
+		{"toml", "\"<C-s>\" = \"Save\"\n\"<M-o>\" = \"Open File\"\n"},
+		{"yaml", "<C-s>: Save\n<M-o>: Open File\n"},
+		{"YML", "<C-s>: Save\n<M-o>: Open File\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			km, err := ParseKeymap([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(km, want) {
+				t.Errorf("ParseKeymap() = %v, want %v", km, want)
+			}
+		})
+	}
+}
+
+func TestParseKeymapErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		data   string
+	}{
+		{"ini", "<C-s> = Save"},
+		{"json", `{"<C-s>": `},
+		{"json", `{"<C-s>": 1}`},
+		{"toml", "<C-s> = Save"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseKeymap([]byte(tt.data), tt.format); err == nil {
+			t.Errorf("ParseKeymap(%q, %q) succeeded", tt.data, tt.format)
+		}
+	}
+}
+
+func TestIsValidKey(t *testing.T) {
+	tests := []struct {
+		seq  string
+		want bool
+	}{
+		{"a", true},
+		{"é", true},
+		{"ab", false},
+		{KeyCtrlS, true},
+		{"<c-s>", true},
+		{KeyF5, true},
+		{"<M-f>", true},
+		{"<M-<C-x>>", true},
+		{"<M-<M-f>>", false},
+		{"<C-Nope>", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isValidKey(tt.seq); got != tt.want {
+			t.Errorf("isValidKey(%q) = %v, want %v", tt.seq, got, tt.want)
+		}
+	}
+}
+
+// commandKeys returns the keys bound to the named command.
+func commandKeys(ui *tcellUI, name string) []string {
+	var keys []string
+	for _, b := range ui.keybindings {
+		if b.command == name {
+			keys = append(keys, b.sequence)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newKeymapUI(t *testing.T) *tcellUI {
+	ui, err := newTcellUI(NewLabel(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.RegisterCommand(Command{Name: "Save", Keys: []string{KeyCtrlS}, Handler: func() {}})
+	ui.RegisterCommand(Command{Name: "Open", Keys: []string{KeyCtrlO}, Handler: func() {}})
+	return ui
+}
+
+func TestApplyKeymap(t *testing.T) {
+	ui := newKeymapUI(t)
+
+	if err := ui.ApplyKeymap(Keymap{"<M-s>": "Save", "<F2>": "Save"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := commandKeys(ui, "Save"), []string{"<F2>", "<M-s>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Save is bound to %q, want %q", got, want)
+	}
+	if got, want := commandKeys(ui, "Open"), []string{KeyCtrlO}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Open is bound to %q, want %q", got, want)
+	}
+
+	// Commands registered later pick up the keymap too.
+	ui.RegisterCommand(Command{Name: "Quit", Keys: []string{"<C-w>"}})
+	if err := ui.ApplyKeymap(Keymap{"<M-q>": "Quit"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := commandKeys(ui, "Save"), []string{KeyCtrlS}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Save left out of the keymap is bound to %q, want %q", got, want)
+	}
+	if got, want := commandKeys(ui, "Quit"), []string{"<M-q>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Quit is bound to %q, want %q", got, want)
+	}
+}
+
+func TestApplyKeymapErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		km   Keymap
+		want KeymapError
+	}{
+		{
+			name: "invalid key",
+			km:   Keymap{"<C-Nope>": "Save"},
+			want: KeymapError{Invalid: []string{"<C-Nope>"}},
+		},
+		{
+			name: "unknown action",
+			km:   Keymap{"<F2>": "Print"},
+			want: KeymapError{Unknown: []string{"Print"}},
+		},
+		{
+			name: "default key of another command",
+			km:   Keymap{KeyCtrlO: "Save"},
+			want: KeymapError{Conflicts: []KeymapConflict{{Key: KeyCtrlO, Actions: []string{"Save", "Open"}}}},
+		},
+		{
+			name: "quit key",
+			km:   Keymap{KeyCtrlQ: "Save"},
+			want: KeymapError{Conflicts: []KeymapConflict{{Key: KeyCtrlQ, Actions: []string{"Save", "quit"}}}},
+		},
+		{
+			name: "help key in another case",
+			km:   Keymap{"<f1>": "Open"},
+			want: KeymapError{Conflicts: []KeymapConflict{{Key: "<f1>", Actions: []string{"Open", "help"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := newKeymapUI(t)
+			err := ui.ApplyKeymap(tt.km)
+			var kerr *KeymapError
+			if !errors.As(err, &kerr) {
+				t.Fatalf("ApplyKeymap() = %v, want a *KeymapError", err)
+			}
+			if !reflect.DeepEqual(*kerr, tt.want) {
+				t.Errorf("ApplyKeymap() = %+v, want %+v", *kerr, tt.want)
+			}
+			if got, want := commandKeys(ui, "Save"), []string{KeyCtrlS}; !reflect.DeepEqual(got, want) {
+				t.Errorf("a bad keymap rebound Save to %q", got)
+			}
+		})
+	}
+}
+
+func TestLoadKeymap(t *testing.T) {
+	ui := newKeymapUI(t)
+	if err := ui.ReloadKeymap(); err == nil {
+		t.Error("ReloadKeymap succeeded without a loaded keymap")
+	}
+
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	if err := ioutil.WriteFile(path, []byte("<F2>: Save\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.LoadKeymap(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := commandKeys(ui, "Save"), []string{"<F2>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Save is bound to %q, want %q", got, want)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("<F3>: Save\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ReloadKeymap(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := commandKeys(ui, "Save"), []string{"<F3>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after reloading Save is bound to %q, want %q", got, want)
+	}
+}
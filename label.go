@@ -19,9 +19,6 @@ type Label struct {
 	text     string
 	wordWrap bool
 
-	// cache the result of SizeHint() (see #14)
-	cacheSizeHint *image.Point
-
 	styleName string
 }
 
@@ -37,10 +34,13 @@ func NewLabel(text string) *Label {
 
 // Resize changes the size of the Widget.
 func (l *Label) Resize(pos, size image.Point) {
-	if l.size != size {
-		l.cacheSizeHint = nil
+	// Wrapped text is as tall as the width makes it, so the ancestors are
+	// laid out again with the new height.
+	if l.wordWrap && size.X != l.Width {
+		l.Invalidate()
+		l.Refresh()
 	}
-	l.widgetBlock.SetRect(pos.X, pos.Y, pos.X+size.X, pos.Y+size.Y)
+	l.widgetBlock.SetRect(pos.X, pos.Y, size.X, size.Y)
 }
 
 func (l *Label) draw() {
@@ -93,14 +93,12 @@ func (l *Label) drawLine(line string, p *Painter) {
 
 // MinSizeHint returns the minimum size the widget is allowed to be.
 func (l *Label) MinSizeHint() image.Point {
-	return image.Point{1, 1}
+	return image.Point{1, 1}.Add(l.FrameSize())
 }
 
-// SizeHint returns the recommended size for the label.
+// SizeHint returns the recommended size for the label, including its border
+// and padding.
 func (l *Label) SizeHint() image.Point {
-	if l.cacheSizeHint != nil {
-		return *l.cacheSizeHint
-	}
 	var max int
 	lines := l.lines()
 	for _, line := range lines {
@@ -108,16 +106,13 @@ func (l *Label) SizeHint() image.Point {
 			max = w
 		}
 	}
-	sizeHint := image.Point{max, len(lines)}
-	l.cacheSizeHint = &sizeHint
-	return sizeHint
+	return image.Point{max, len(lines)}.Add(l.FrameSize())
 }
 
 func (l *Label) lines() []string {
 	txt := l.text
-	if l.wordWrap {
-		txt = wordwrap.WrapString(l.text, uint(l.GetInner().Size().X))
-		txt = wordwrap.WrapString(l.text, 5)
+	if width := l.GetInner().Dx(); l.wordWrap && width > 0 {
+		txt = wordwrap.WrapString(l.text, uint(width))
 	}
 	return strings.Split(txt, "\n")
 }
@@ -131,14 +126,15 @@ func (l *Label) Text() string {
 func (l *Label) SetText(text string) {
 	l.Lock()
 	defer l.Unlock()
-	l.cacheSizeHint = nil
 	l.text = text
+	l.Invalidate()
 	l.rePaint(l)
 }
 
 // SetWordWrap sets whether text content should be wrapped.
 func (l *Label) SetWordWrap(enabled bool) {
 	l.wordWrap = enabled
+	l.Invalidate()
 }
 
 // SetStyleName sets the identifier used for custom styling.
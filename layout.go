@@ -0,0 +1,196 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+// layoutCache holds the size hints of a widget between layouts.
+type layoutCache struct {
+	hint, min image.Point
+	valid     bool
+	// dirty is set when the children of the widget must be laid out again
+	// even if the widget keeps its size.
+	dirty bool
+}
+
+// based is implemented by widgets built on a WidgetBase.
+type based interface {
+	base() *WidgetBase
+}
+
+func (s *WidgetBase) base() *WidgetBase {
+	return s
+}
+
+// Invalidate drops the cached size hints of the widget and its ancestors, and
+// has them laid out again on the next repaint. The setters of the widgets call
+// it; call it after changing a field that affects the size of a widget, such
+// as Padding or Border, directly.
+func (s *WidgetBase) Invalidate() {
+	for b := s; b != nil; {
+		b.hints.valid = false
+		b.hints.dirty = true
+		p, ok := b.parent.(based)
+		if !ok {
+			return
+		}
+		b = p.base()
+	}
+}
+
+// needsLayout reports whether a widget given pos and size has to lay out its
+// children, because its rectangle changed or its layout was invalidated.
+func (s *WidgetBase) needsLayout(pos, size image.Point) bool {
+	changed := s.hints.dirty ||
+		pos.X != s.X || pos.Y != s.Y || size.X != s.Width || size.Y != s.Height
+	s.hints.dirty = false
+	return changed
+}
+
+// hintsOf returns the size hint and minimum size hint of w, from the cache
+// if they haven't been invalidated.
+func hintsOf(w Widget) (hint, min image.Point) {
+	b, ok := w.(based)
+	if !ok {
+		return w.SizeHint(), w.MinSizeHint()
+	}
+	c := &b.base().hints
+	if !c.valid {
+		c.hint, c.min = w.SizeHint(), w.MinSizeHint()
+		c.valid = true
+	}
+	return c.hint, c.min
+}
+
+// sizeHintOf returns the cached size hint of w.
+func sizeHintOf(w Widget) image.Point {
+	hint, _ := hintsOf(w)
+	return hint
+}
+
+// minSizeHintOf returns the cached minimum size hint of w.
+func minSizeHintOf(w Widget) image.Point {
+	_, min := hintsOf(w)
+	return min
+}
+
+// doLayout shares space between ws along a. The widgets first get their
+// minimum size, then the Minimum, Preferred and Maximum widgets grow to their
+// size hint, the Expanding widgets share what is left, and without any
+// Expanding widget the rest goes to the smallest Minimum and Preferred ones.
+// Within each step every widget grows by the same amount, so the space is
+// given out in proportion rather than a cell at a time.
+func doLayout(ws []Widget, space int, a LayoutMode) []int {
+	n := len(ws)
+	sizes := make([]int, n)
+	if n == 0 || space <= 0 {
+		return sizes
+	}
+
+	hints := make([]int, n)
+	mins := make([]int, n)
+	policies := make([]SizePolicy, n)
+	for i, w := range ws {
+		hint, min := hintsOf(w)
+		hints[i], mins[i] = dim(a, hint), dim(a, min)
+		policies[i] = alignedSizePolicy(a, w)
+	}
+	targets := func(match func(SizePolicy) bool, from []int) []int {
+		t := make([]int, n)
+		for i, p := range policies {
+			if match(p) {
+				t[i] = from[i]
+			}
+		}
+		return t
+	}
+	unbounded := make([]int, n)
+	for i := range unbounded {
+		unbounded[i] = maxInt
+	}
+
+	rest := grow(sizes, mins, space)
+	rest = grow(sizes, targets(func(p SizePolicy) bool { return p == Minimum }, hints), rest)
+	rest = grow(sizes, targets(func(p SizePolicy) bool { return p == Preferred || p == Maximum }, hints), rest)
+	rest = grow(sizes, targets(func(p SizePolicy) bool { return p == Expanding }, unbounded), rest)
+	level(sizes, targets(func(p SizePolicy) bool { return p == Preferred || p == Minimum }, unbounded), rest)
+	return sizes
+}
+
+// grow raises each size towards its target by the same amount, within
+// budget. When the budget runs out the first sizes get the odd cells. It
+// returns what is left of the budget.
+func grow(sizes, targets []int, budget int) int {
+	for budget > 0 {
+		var active []int
+		step := maxInt
+		for i, sz := range sizes {
+			if sz < targets[i] {
+				active = append(active, i)
+				step = MinInt(step, targets[i]-sz)
+			}
+		}
+		if len(active) == 0 {
+			break
+		}
+		if step > budget/len(active) {
+			spread(sizes, active, budget)
+			return 0
+		}
+		for _, i := range active {
+			sizes[i] += step
+		}
+		budget -= step * len(active)
+	}
+	return budget
+}
+
+// level spends budget on the smallest of the sizes whose target isn't zero,
+// bringing them up to the next smallest, until the budget runs out.
+func level(sizes, targets []int, budget int) {
+	for budget > 0 {
+		low, next := maxInt, maxInt
+		for i, sz := range sizes {
+			if targets[i] == 0 {
+				continue
+			}
+			if sz < low {
+				low, next = sz, low
+			} else if sz > low && sz < next {
+				next = sz
+			}
+		}
+		if low == maxInt {
+			return
+		}
+		var active []int
+		for i, sz := range sizes {
+			if targets[i] != 0 && sz == low {
+				active = append(active, i)
+			}
+		}
+		step := next - low
+		if next == maxInt || step > budget/len(active) {
+			spread(sizes, active, budget)
+			return
+		}
+		for _, i := range active {
+			sizes[i] += step
+		}
+		budget -= step * len(active)
+	}
+}
+
+// spread shares budget equally between the sizes at the given indices, the
+// first ones getting the odd cells.
+func spread(sizes, active []int, budget int) {
+	q, r := budget/len(active), budget%len(active)
+	for j, i := range active {
+		sizes[i] += q
+		if j < r {
+			sizes[i]++
+		}
+	}
+}
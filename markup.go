@@ -0,0 +1,304 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// ViewPollInterval is how often WatchView checks the markup file for changes.
+var ViewPollInterval = 500 * time.Millisecond
+
+// Markup describes a widget and its children. The type is one of "hbox",
+// "vbox", "block", "label", "input", "scroll", "stack", "hsplit" or "vsplit".
+//
+// A markup file holds the root widget, e.g. in YAML:
+//
+//	type: vbox
+//	children:
+//	  - type: label
+//	    id: status
+//	    text: Ready
+//	    height: 3
+//	  - type: input
+//	    id: query
+//	    title: Search
+//	    padding: [0, 1]
+type Markup struct {
+	Type  string `json:"type" yaml:"type" toml:"type"`
+	ID    string `json:"id" yaml:"id" toml:"id"`
+	Text  string `json:"text" yaml:"text" toml:"text"`
+	Title string `json:"title" yaml:"title" toml:"title"`
+	// Border shows or hides the border. Unset keeps the default of the
+	// widget type.
+	Border *bool `json:"border" yaml:"border" toml:"border"`
+	// Padding and Margin hold one value for all sides, two for top and
+	// bottom then left and right, or four for top, right, bottom and left.
+	Padding []int `json:"padding" yaml:"padding" toml:"padding"`
+	Margin  []int `json:"margin" yaml:"margin" toml:"margin"`
+	// Width and Height fix the size of the widget, including its border.
+	// Zero leaves it to the layout.
+	Width    int      `json:"width" yaml:"width" toml:"width"`
+	Height   int      `json:"height" yaml:"height" toml:"height"`
+	Spacing  int      `json:"spacing" yaml:"spacing" toml:"spacing"`
+	Children []Markup `json:"children" yaml:"children" toml:"children"`
+}
+
+// View is a widget tree built from markup.
+type View struct {
+	Root Widget
+	ids  map[string]Widget
+}
+
+// Widget returns the widget with the given ID, or nil.
+func (v *View) Widget(id string) Widget {
+	return v.ids[id]
+}
+
+// IDs returns the IDs of the widgets of the view.
+func (v *View) IDs() []string {
+	ids := make([]string, 0, len(v.ids))
+	for id := range v.ids {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ParseMarkup parses markup in the given format, which is one of "json",
+// "toml" or "yaml".
+func ParseMarkup(data []byte, format string) (*Markup, error) {
+	var m Markup
+	var err error
+	switch strings.ToLower(format) {
+	case "json":
+		err = json.Unmarshal(data, &m)
+	case "toml":
+		err = toml.Unmarshal(data, &m)
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &m)
+	default:
+		return nil, fmt.Errorf("termuix: unknown markup format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("termuix: parsing markup: %v", err)
+	}
+	return &m, nil
+}
+
+// ReadView reads a markup file and builds its widgets. The format is taken
+// from the file extension.
+func ReadView(path string) (*View, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ParseMarkup(data, strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return nil, err
+	}
+	return m.Build()
+}
+
+// Build creates the widgets described by the markup.
+func (m *Markup) Build() (*View, error) {
+	v := &View{ids: make(map[string]Widget)}
+	root, err := m.build(v, m.Type)
+	if err != nil {
+		return nil, err
+	}
+	v.Root = root
+	return v, nil
+}
+
+func (m *Markup) build(v *View, path string) (Widget, error) {
+	if m.ID != "" {
+		path = fmt.Sprintf("%s %q", m.Type, m.ID)
+	}
+	fail := func(format string, args ...interface{}) (Widget, error) {
+		return nil, fmt.Errorf("termuix: markup %s: %s", path, fmt.Sprintf(format, args...))
+	}
+
+	children := make([]Widget, len(m.Children))
+	for i := range m.Children {
+		c := &m.Children[i]
+		w, err := c.build(v, fmt.Sprintf("%s > %s[%d]", path, c.Type, i))
+		if err != nil {
+			return nil, err
+		}
+		children[i] = w
+	}
+
+	var w Widget
+	leaf := false
+	switch strings.ToLower(m.Type) {
+	case "hbox":
+		b := NewHBox(children...)
+		b.SetSpacing(m.Spacing)
+		w = b
+	case "vbox":
+		b := NewVBox(children...)
+		b.SetSpacing(m.Spacing)
+		w = b
+	case "block":
+		b := NewBlock()
+		for _, c := range children {
+			b.Append(c)
+		}
+		w = b
+	case "label":
+		w, leaf = NewLabel(m.Text), true
+	case "input":
+		in := NewInput()
+		in.SetText(m.Text)
+		w, leaf = in, true
+	case "scroll":
+		if len(children) != 1 {
+			return fail("a scroll view needs exactly one child, got %d", len(children))
+		}
+		w = NewScrollView(children[0])
+	case "stack":
+		w = NewStack(children...)
+	case "hsplit":
+		w = NewHSplitter(children...)
+	case "vsplit":
+		w = NewVSplitter(children...)
+	case "":
+		return fail("missing type")
+	default:
+		return fail("unknown type %q", m.Type)
+	}
+	if leaf && len(children) > 0 {
+		return fail("a %s can't have children", m.Type)
+	}
+	if !leaf && m.Text != "" {
+		return fail("a %s can't have text", m.Type)
+	}
+
+	b := w.(blocked).block()
+	if m.Title != "" {
+		b.Title = m.Title
+	}
+	if m.Border != nil {
+		b.Border = *m.Border
+	}
+	var err error
+	if b.PaddingTop, b.PaddingRight, b.PaddingBottom, b.PaddingLeft, err = sides(m.Padding); err != nil {
+		return fail("padding: %v", err)
+	}
+	if b.MarginTop, b.MarginRight, b.MarginBottom, b.MarginLeft, err = sides(m.Margin); err != nil {
+		return fail("margin: %v", err)
+	}
+	if m.Width != 0 || m.Height != 0 {
+		w.(constrained).SetConstraints(Constraints{
+			Width:  Constraint{Exact: m.Width},
+			Height: Constraint{Exact: m.Height},
+		})
+	}
+
+	if m.ID != "" {
+		if _, ok := v.ids[m.ID]; ok {
+			return fail("duplicate id")
+		}
+		v.ids[m.ID] = w
+	}
+	return w, nil
+}
+
+// sides expands the CSS-like shorthand of Markup.Padding and Markup.Margin.
+func sides(v []int) (top, right, bottom, left int, err error) {
+	switch len(v) {
+	case 0:
+	case 1:
+		top, right, bottom, left = v[0], v[0], v[0], v[0]
+	case 2:
+		top, right, bottom, left = v[0], v[1], v[0], v[1]
+	case 4:
+		top, right, bottom, left = v[0], v[1], v[2], v[3]
+	default:
+		err = fmt.Errorf("want 1, 2 or 4 values, got %d", len(v))
+	}
+	return
+}
+
+// LoadView reads a markup file, builds its widgets and shows them as the root
+// widget. fn, if not nil, is called with the view before it is shown, e.g.
+// to attach handlers to the widgets.
+func (ui *tcellUI) LoadView(path string, fn func(v *View)) error {
+	v, err := ReadView(path)
+	if err != nil {
+		return err
+	}
+	ui.showView(v, fn)
+	return nil
+}
+
+func (ui *tcellUI) showView(v *View, fn func(v *View)) {
+	if fn != nil {
+		fn(v)
+	}
+	ui.SetWidget(v.Root)
+	ui.Repaint()
+}
+
+// WatchView loads a markup file like LoadView, then polls the file and
+// rebuilds the view whenever it changes, until stop is called. A file that
+// fails to build is logged and the current view is kept.
+func (ui *tcellUI) WatchView(path string, fn func(v *View)) (stop func(), err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := ui.LoadView(path, fn); err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		modTime := info.ModTime()
+		ticker := time.NewTicker(ViewPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			info, err := os.Stat(path)
+			if err != nil || info.ModTime().Equal(modTime) {
+				continue
+			}
+			modTime = info.ModTime()
+			v, err := ReadView(path)
+			if err != nil {
+				logger.Printf("%v", err)
+				continue
+			}
+			// Unlike Update this doesn't wait, so that stop isn't kept
+			// waiting by a UI that has stopped running.
+			select {
+			case ui.eventQueue <- Event{Type: CallbackEvent, ID: "<Callback>", Payload: func() {
+				ui.showView(v, fn)
+			}}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMarkup(t *testing.T) {
+	no := false
+	want := &Markup{
+		Type:    "vbox",
+		Spacing: 1,
+		Children: []Markup{
+			{Type: "label", ID: "status", Text: "Ready", Height: 3},
+			{Type: "input", ID: "query", Title: "Search", Border: &no, Padding: []int{0, 1}},
+		},
+	}
+	tests := []struct {
+		format string
+		data   string
+	}{
+		{"json", `{
+			"type": "vbox",
+			"spacing": 1,
+			"children": [
+				{"type": "label", "id": "status", "text": "Ready", "height": 3},
+				{"type": "input", "id": "query", "title": "Search", "border": false, "padding": [0, 1]}
+			]
+		}`},
+		{"toml", `
+type = "vbox"
+spacing = 1
+
+[[children]]
+type = "label"
+id = "status"
+text = "Ready"
+height = 3
+
+[[children]]
+type = "input"
+id = "query"
+title = "Search"
+border = false
+padding = [0, 1]
+`},
+		{"yaml", `
+type: vbox
+spacing: 1
+children:
+  - type: label
+    id: status
+    text: Ready
+    height: 3
+  - type: input
+    id: query
+    title: Search
+    border: false
+    padding: [0, 1]
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			m, err := ParseMarkup([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(m, want) {
+				t.Errorf("ParseMarkup() = %+v, want %+v", m, want)
+			}
+		})
+	}
+}
+
+func TestParseMarkupErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		data   string
+	}{
+		{"xml", "<vbox/>"},
+		{"json", `{"type": "vbox", "children": {}}`},
+		{"yaml", "type: [vbox"},
+		{"toml", "type = vbox"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseMarkup([]byte(tt.data), tt.format); err == nil {
+			t.Errorf("ParseMarkup(%q, %q) succeeded", tt.data, tt.format)
+		}
+	}
+}
+
+func TestMarkupBuild(t *testing.T) {
+	no := false
+	m := Markup{
+		Type: "vbox",
+		ID:   "root",
+		Children: []Markup{
+			{Type: "label", ID: "status", Text: "Ready", Width: 10, Height: 3},
+			{Type: "input", ID: "query", Text: "go", Title: "Search", Border: &no, Padding: []int{0, 1}},
+			{Type: "scroll", Children: []Markup{{Type: "block", Margin: []int{1, 2, 3, 4}}}},
+		},
+	}
+	v, err := m.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, ok := v.Widget("root").(*Box)
+	if !ok || Widget(root) != v.Root {
+		t.Fatalf("root is %T, want the *Box at the root of the view", v.Widget("root"))
+	}
+	if n := len(root.Children()); n != 3 {
+		t.Fatalf("root has %d children, want 3", n)
+	}
+
+	status, ok := v.Widget("status").(*Label)
+	if !ok {
+		t.Fatalf("status is %T, want *Label", v.Widget("status"))
+	}
+	if status.Text() != "Ready" {
+		t.Errorf("status text = %q, want %q", status.Text(), "Ready")
+	}
+	c := status.Constraints()
+	if c.Width.Exact != 10 || c.Height.Exact != 3 {
+		t.Errorf("status constraints = %+v, want an exact 10x3", c)
+	}
+
+	query, ok := v.Widget("query").(*Input)
+	if !ok {
+		t.Fatalf("query is %T, want *Input", v.Widget("query"))
+	}
+	if query.Text() != "go" || query.Title != "Search" || query.Border {
+		t.Errorf("query has text %q, title %q and border %v", query.Text(), query.Title, query.Border)
+	}
+	if query.PaddingTop != 0 || query.PaddingRight != 1 || query.PaddingBottom != 0 || query.PaddingLeft != 1 {
+		t.Errorf("query padding = %d %d %d %d, want 0 1 0 1",
+			query.PaddingTop, query.PaddingRight, query.PaddingBottom, query.PaddingLeft)
+	}
+
+	scroll := root.Children()[2]
+	if _, ok := scroll.(*ScrollView); !ok {
+		t.Fatalf("third child is %T, want *ScrollView", scroll)
+	}
+	block := scroll.Children()[0].(*Block)
+	if block.MarginTop != 1 || block.MarginRight != 2 || block.MarginBottom != 3 || block.MarginLeft != 4 {
+		t.Errorf("block margin = %d %d %d %d, want 1 2 3 4",
+			block.MarginTop, block.MarginRight, block.MarginBottom, block.MarginLeft)
+	}
+
+	ids := v.IDs()
+	if len(ids) != 3 {
+		t.Errorf("IDs() = %q, want root, status and query", ids)
+	}
+}
+
+func TestMarkupBuildErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Markup
+		want string
+	}{
+		{"missing type", Markup{}, "missing type"},
+		{"unknown type", Markup{Type: "grid"}, `unknown type "grid"`},
+		{"leaf with children", Markup{Type: "label", Children: []Markup{{Type: "label"}}}, "can't have children"},
+		{"container with text", Markup{Type: "vbox", Text: "hi"}, "can't have text"},
+		{"scroll without child", Markup{Type: "scroll"}, "exactly one child, got 0"},
+		{"bad padding", Markup{Type: "block", Padding: []int{1, 2, 3}}, "padding: want 1, 2 or 4 values, got 3"},
+		{
+			"duplicate id",
+			Markup{Type: "hbox", Children: []Markup{{Type: "label", ID: "a"}, {Type: "input", ID: "a"}}},
+			`input "a": duplicate id`,
+		},
+		{
+			"error in a child",
+			Markup{Type: "vbox", Children: []Markup{{Type: "hbox"}, {Type: "nope"}}},
+			`vbox > nope[1]: unknown type "nope"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.m.Build()
+			if err == nil {
+				t.Fatal("Build succeeded")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Build() = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package termuix
 import (
 	rw "github.com/mattn/go-runewidth"
 	"image"
+	"sync"
 )
 
 // Surface defines a surface that can be painted on.
@@ -27,7 +28,21 @@ type Painter struct {
 	surface Surface
 	// Transform stack
 	transforms []image.Point
-	drawQueue  chan Widget
+	// Clip stack; painting outside the topmost rectangle is discarded.
+	clips     []image.Rectangle
+	drawQueue chan Widget
+	// popups are drawn on top of the widgets at the end of a repaint.
+	popups []func()
+	// size is the size of the screen at the last repaint.
+	size image.Point
+	// onUnmount is called when a widget is removed from the tree.
+	onUnmount func(w Widget)
+
+	// posted holds the functions queued by Post for the UI goroutine.
+	postMu sync.Mutex
+	posted []func()
+	// wake is signalled when functions are posted.
+	wake chan struct{}
 }
 
 // NewPainter returns a new instance of Painter.
@@ -35,6 +50,7 @@ func NewPainter() *Painter {
 	return &Painter{
 		surface:   NewScreen(image.Rectangle{}),
 		drawQueue: make(chan Widget, 100),
+		wake:      make(chan struct{}, 1),
 	}
 }
 
@@ -50,6 +66,27 @@ func (p *Painter) Restore() {
 	}
 }
 
+// PushClip restricts painting to r, within the current clipping rectangle.
+func (p *Painter) PushClip(r image.Rectangle) {
+	if n := len(p.clips); n > 0 {
+		r = r.Intersect(p.clips[n-1])
+	}
+	p.clips = append(p.clips, r)
+}
+
+// PopClip restores the clipping rectangle before the last PushClip.
+func (p *Painter) PopClip() {
+	if len(p.clips) > 0 {
+		p.clips = p.clips[:len(p.clips)-1]
+	}
+}
+
+// clipped reports whether pt is outside the clipping rectangle.
+func (p *Painter) clipped(pt image.Point) bool {
+	n := len(p.clips)
+	return n > 0 && !pt.In(p.clips[n-1])
+}
+
 // Begin prepares the surface for painting.
 func (p *Painter) Begin() {
 	p.surface.Clear()
@@ -60,20 +97,63 @@ func (p *Painter) End() {
 	p.surface.Show()
 }
 
+// addPaint asks for a repaint. It never blocks: the whole scene is drawn for
+// any number of pending requests, so a full queue already holds one.
 func (p *Painter) addPaint(w Widget) {
-	p.drawQueue <- w
+	select {
+	case p.drawQueue <- w:
+	default:
+	}
+}
+
+// post queues fn to be run on the UI goroutine. It never blocks.
+func (p *Painter) post(fn func()) {
+	p.postMu.Lock()
+	p.posted = append(p.posted, fn)
+	p.postMu.Unlock()
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// takePosted returns the posted functions and empties the queue.
+func (p *Painter) takePosted() []func() {
+	p.postMu.Lock()
+	defer p.postMu.Unlock()
+	fns := p.posted
+	p.posted = nil
+	return fns
 }
 
-// Repaint clears the surface, draws the scene and flushes it.
-func (p *Painter) Repaint(w Widget) {
+// Repaint clears the surface, draws the widgets in order and flushes it.
+func (p *Painter) Repaint(ws ...Widget) {
 	p.surface.HideCursor()
 	p.Begin()
-	w.Draw()
+	for _, w := range ws {
+		w.Draw()
+	}
+	for len(p.popups) > 0 {
+		fn := p.popups[0]
+		p.popups = p.popups[1:]
+		fn()
+	}
 	p.End()
 }
 
+// DrawPopup runs fn once the widgets being repainted have been drawn, so that
+// it draws on top of them. It is meant for content that extends past the
+// area of a widget, like the suggestions of an Input.
+func (p *Painter) DrawPopup(fn func()) {
+	p.popups = append(p.popups, fn)
+}
+
 // DrawCursor draws the cursor at the given position.
 func (p *Painter) DrawCursor(x, y int) {
+	if p.clipped(image.Pt(x, y)) {
+		return
+	}
 	p.surface.SetCursor(x, y)
 }
 
@@ -95,10 +175,16 @@ func (self *Painter) GetCell(p image.Point) Cell {
 }
 
 func (self *Painter) SetCell(c Cell, p image.Point) {
+	if self.clipped(p) {
+		return
+	}
 	self.surface.SetCell(c, p)
 }
 
 func (self *Painter) Fill(c Cell, rect image.Rectangle) {
+	if n := len(self.clips); n > 0 {
+		rect = rect.Intersect(self.clips[n-1])
+	}
 	self.surface.Fill(c, rect)
 }
 
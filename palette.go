@@ -0,0 +1,209 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"sort"
+	"strings"
+)
+
+const (
+	paletteWidth    = 60
+	paletteMaxItems = 10
+)
+
+// paletteItem is a command matching the query of the palette.
+type paletteItem struct {
+	cmd     *Command
+	score   int
+	matched []int
+}
+
+// commandPalette is an overlay that fuzzy-matches the registered commands
+// against a query and runs the chosen one.
+type commandPalette struct {
+	Block
+	ui    *tcellUI
+	input *Input
+
+	items    []paletteItem
+	selected int
+	top      int
+}
+
+func newCommandPalette(ui *tcellUI) *commandPalette {
+	p := &commandPalette{
+		Block: *NewBlock(),
+		ui:    ui,
+		input: NewInput(),
+	}
+	p.Title = "Commands"
+	p.BorderStyle = Theme.Palette.Border
+	p.input.Border = false
+	p.input.OnChanged(func(*Input) {
+		p.filter()
+	})
+	p.Append(p.input)
+	p.filter()
+	return p
+}
+
+// filter updates the list of commands matching the query.
+func (p *commandPalette) filter() {
+	query := strings.TrimSpace(p.input.Text())
+	p.items = p.items[:0]
+	for _, c := range p.ui.commands.list() {
+		score, matched, ok := fuzzyMatch(query, c.title())
+		if !ok {
+			continue
+		}
+		p.items = append(p.items, paletteItem{cmd: c, score: score, matched: matched})
+	}
+	if query != "" {
+		sort.SliceStable(p.items, func(i, j int) bool {
+			return p.items[i].score > p.items[j].score
+		})
+	}
+	p.selected = 0
+	p.top = 0
+}
+
+// Resize lays out the query line and the list of commands.
+func (p *commandPalette) Resize(pos image.Point, size image.Point) {
+	p.SetRect(pos.X, pos.Y, size.X, size.Y)
+	inner := p.GetInner()
+	p.input.Resize(image.Pt(0, 0), image.Pt(inner.Dx(), 1))
+}
+
+// SizeHint returns the size needed to show the query and the list.
+func (p *commandPalette) SizeHint() image.Point {
+	rows := len(p.ui.commands.commands)
+	if rows > paletteMaxItems {
+		rows = paletteMaxItems
+	}
+	return image.Pt(paletteWidth, rows+3)
+}
+
+// MinSizeHint returns the minimum size of the palette.
+func (p *commandPalette) MinSizeHint() image.Point {
+	return image.Pt(20, 4)
+}
+
+func (p *commandPalette) visibleRows() int {
+	return p.GetInner().Dy() - 1
+}
+
+// Draw draws the query and the matching commands.
+func (p *commandPalette) Draw() {
+	p.Lock()
+	defer p.Unlock()
+
+	painter := p.GetPainter()
+	if painter == nil {
+		return
+	}
+	painter.Fill(Cell{' ', Theme.Palette.Text}, p.GetOuter())
+	p.Block.draw()
+	p.input.Draw()
+
+	inner := p.GetInnerRealPos()
+	for i := 0; i < p.visibleRows() && p.top+i < len(p.items); i++ {
+		item := p.items[p.top+i]
+		y := inner.Min.Y + 1 + i
+		style := Theme.Palette.Text
+		if p.top+i == p.selected {
+			style = Theme.Palette.Selected
+		}
+		painter.Fill(Cell{' ', style}, image.Rect(inner.Min.X, y, inner.Max.X, y+1))
+		p.drawItem(painter, item, image.Rect(inner.Min.X, y, inner.Max.X, y+1), style)
+	}
+}
+
+// drawItem draws the title of a command with the matched runes highlighted,
+// its description and its keys aligned to the right.
+func (p *commandPalette) drawItem(painter *Painter, item paletteItem, r image.Rectangle, style Style) {
+	keys := strings.Join(item.cmd.Keys, " ")
+	keyStyle := style.mergeIn(Theme.Palette.Key)
+	maxX := r.Max.X - stringWidth(keys) - 1
+	painter.DrawText(maxX+1, r.Min.Y, keys, &keyStyle)
+
+	x := r.Min.X
+	matched := make(map[int]bool, len(item.matched))
+	for _, i := range item.matched {
+		matched[i] = true
+	}
+	for i, ch := range []rune(item.cmd.title()) {
+		if x+runeWidth(ch) > maxX {
+			return
+		}
+		st := style
+		if matched[i] {
+			st = style.mergeIn(Theme.Palette.Match)
+		}
+		painter.DrawRune(x, r.Min.Y, ch, &st)
+		x += runeWidth(ch)
+	}
+	if desc := item.cmd.Description; desc != "" && x+2 < maxX {
+		descStyle := style.mergeIn(Theme.Palette.Description)
+		painter.DrawText(x+2, r.Min.Y, TrimString(desc, maxX-x-2), &descStyle)
+	}
+}
+
+// DoEvent moves the selection, runs the selected command or passes the key
+// on to the query.
+func (p *commandPalette) DoEvent(ev Event) bool {
+	switch ev.Type {
+	case KeyboardEvent:
+		switch ev.ID {
+		case KeyEsc:
+			p.ui.hideOverlay(p)
+		case KeyEnter:
+			p.run(p.selected)
+		case KeyArrowUp, KeyCtrlP:
+			p.move(-1)
+		case KeyArrowDown, KeyCtrlN:
+			p.move(1)
+		default:
+			p.input.DoEvent(ev)
+		}
+		p.rePaint(p)
+		return true
+	case MouseEvent:
+		m := ev.Payload.(Mouse)
+		inner := p.GetInnerRealPos()
+		row := m.Y - inner.Min.Y - 1
+		if ev.ID == "<MouseLeft>" && row >= 0 && row < p.visibleRows() &&
+			image.Pt(m.X, m.Y).In(inner) {
+			p.run(p.top + row)
+		}
+		return true
+	}
+	return false
+}
+
+func (p *commandPalette) move(d int) {
+	if len(p.items) == 0 {
+		return
+	}
+	p.selected = (p.selected + d + len(p.items)) % len(p.items)
+	if p.selected < p.top {
+		p.top = p.selected
+	}
+	if rows := p.visibleRows(); p.selected >= p.top+rows {
+		p.top = p.selected - rows + 1
+	}
+}
+
+// run closes the palette and runs the command at index i.
+func (p *commandPalette) run(i int) {
+	if i < 0 || i >= len(p.items) {
+		return
+	}
+	p.ui.hideOverlay(p)
+	if h := p.items[i].cmd.Handler; h != nil {
+		h()
+	}
+}
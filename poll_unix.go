@@ -0,0 +1,69 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package termuix
+
+import (
+	"time"
+
+	tb "github.com/nsf/termbox-go"
+)
+
+// escDelay is how long the start of an escape sequence waits for the rest of
+// it, when the terminal sends it in pieces, before it is taken as a key.
+const escDelay = 50 * time.Millisecond
+
+// rawInput is an event read from termbox with the bytes of raw input.
+type rawInput struct {
+	ev   tb.Event
+	data []byte
+}
+
+// readRawInput reads events from termbox and sends them to ch.
+func readRawInput(ch chan<- rawInput) {
+	for {
+		data := make([]byte, 256)
+		e := tb.PollRawEvent(data)
+		if e.Type == tb.EventRaw {
+			data = data[:e.N]
+		}
+		ch <- rawInput{e, data}
+	}
+}
+
+// pollEvents reads the raw input from termbox and sends the events decoded
+// from it to ch. An incomplete escape sequence is kept until the rest of it
+// arrives or escDelay passes.
+func pollEvents(ch chan<- Event) {
+	raw := make(chan rawInput)
+	go readRawInput(raw)
+
+	var (
+		d       inputDecoder
+		timeout <-chan time.Time
+	)
+	for {
+		var evs []Event
+		select {
+		case in := <-raw:
+			if in.ev.Type != tb.EventRaw {
+				ch <- convertTermboxEvent(in.ev)
+				continue
+			}
+			evs = d.feed(in.data)
+		case <-timeout:
+			evs = d.flush()
+		}
+		for _, ev := range evs {
+			ch <- ev
+		}
+		timeout = nil
+		if d.waiting() {
+			timeout = time.After(escDelay)
+		}
+	}
+}
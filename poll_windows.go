@@ -0,0 +1,17 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package termuix
+
+import tb "github.com/nsf/termbox-go"
+
+// pollEvents sends the events decoded by termbox to ch.
+func pollEvents(ch chan<- Event) {
+	for {
+		ch <- convertTermboxEvent(tb.PollEvent())
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+// popupRows is the number of items a popupList shows at most.
+const popupRows = 8
+
+// popupList is a list drawn on top of the other widgets next to the widget
+// it belongs to, like the suggestions of an Input or the items of a Select.
+type popupList struct {
+	items []string
+	// selected is the selected item, or -1.
+	selected int
+	// top is the first item shown.
+	top int
+	// rect is where the list was last drawn, in screen coordinates.
+	rect image.Rectangle
+}
+
+// set shows items with the given one selected.
+func (l *popupList) set(items []string, selected int) {
+	l.items = items
+	l.selected = selected
+	l.top = 0
+}
+
+// clear hides the list.
+func (l *popupList) clear() {
+	l.set(nil, -1)
+	l.rect = image.Rectangle{}
+}
+
+// move moves the selection n items down, or up if n is negative. With wrap,
+// moving past either end continues from the other one; otherwise the
+// selection stops at the ends.
+func (l *popupList) move(n int, wrap bool) {
+	count := len(l.items)
+	if count == 0 {
+		return
+	}
+	i := l.selected + n
+	if l.selected < 0 && n < 0 {
+		i = count + n
+	}
+	if wrap {
+		l.selected = (i%count + count) % count
+	} else {
+		l.selected = MaxInt(0, MinInt(i, count-1))
+	}
+}
+
+// itemAt returns the item drawn at pt, or -1.
+func (l *popupList) itemAt(pt image.Point) int {
+	if !pt.In(l.rect) {
+		return -1
+	}
+	return l.top + pt.Y - l.rect.Min.Y
+}
+
+// draw draws the list below anchor starting at column x, or above anchor when
+// there is more room there, keeping it within the screen. The list is at
+// least minWidth wide.
+func (l *popupList) draw(p *Painter, anchor image.Rectangle, x, minWidth int, normal, selected Style) {
+	width := minWidth
+	for _, item := range l.items {
+		width = MaxInt(width, stringWidth(item)+2)
+	}
+	height := MinInt(len(l.items), popupRows)
+
+	screen := p.size
+	width = MinInt(width, screen.X)
+	x = MaxInt(0, MinInt(x, screen.X-width))
+	y := anchor.Max.Y
+	below, above := screen.Y-anchor.Max.Y, anchor.Min.Y
+	if height > below && above > below {
+		height = MinInt(height, above)
+		y = anchor.Min.Y - height
+	} else {
+		height = MinInt(height, below)
+	}
+	l.rect = image.Rect(x, y, x+width, y+height)
+	if height <= 0 {
+		return
+	}
+
+	// Scroll to keep the selected item in view.
+	if l.selected >= 0 {
+		if l.selected < l.top {
+			l.top = l.selected
+		}
+		if l.selected >= l.top+height {
+			l.top = l.selected - height + 1
+		}
+	}
+	l.top = MaxInt(0, MinInt(l.top, len(l.items)-height))
+
+	p.PushClip(l.rect)
+	for i := 0; i < height; i++ {
+		style := normal
+		if l.top+i == l.selected {
+			style = selected
+		}
+		p.Fill(NewCell(' ', style), image.Rect(x, y+i, x+width, y+i+1))
+		p.SetString(l.items[l.top+i], style, image.Pt(x+1, y+i))
+	}
+	p.PopClip()
+}
@@ -0,0 +1,229 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+var _ Widget = &RadioGroup{}
+
+// RadioGroup is a list of options of which at most one is selected. The
+// arrow keys move the selection when it's focused, and clicking an option
+// selects it.
+type RadioGroup struct {
+	Block
+	options  []string
+	selected int
+
+	onSelectionChanged func(*RadioGroup)
+}
+
+// NewRadioGroup returns a new RadioGroup with the given options laid out
+// vertically and none selected.
+func NewRadioGroup(options ...string) *RadioGroup {
+	r := &RadioGroup{
+		Block:    *NewBlock(),
+		options:  options,
+		selected: -1,
+	}
+	r.Border = false
+	r.layout = Vertical
+	r.sizePolicyY = Minimum
+	return r
+}
+
+// SetLayoutMode sets whether the options are laid out in a row or in a
+// column.
+func (r *RadioGroup) SetLayoutMode(mode LayoutMode) {
+	r.layout = mode
+	r.Invalidate()
+}
+
+// Options returns the options of the group.
+func (r *RadioGroup) Options() []string {
+	return r.options
+}
+
+// SetOptions replaces the options of the group and clears the selection.
+func (r *RadioGroup) SetOptions(options ...string) {
+	r.options = options
+	r.selected = -1
+	r.Invalidate()
+	r.rePaint(r)
+}
+
+// Selected returns the index of the selected option, or -1 if there is none.
+func (r *RadioGroup) Selected() int {
+	return r.selected
+}
+
+// SelectedOption returns the selected option, or "" if there is none.
+func (r *RadioGroup) SelectedOption() string {
+	if r.selected < 0 {
+		return ""
+	}
+	return r.options[r.selected]
+}
+
+// SetSelected selects the option at index i, or clears the selection if i is
+// -1.
+func (r *RadioGroup) SetSelected(i int) {
+	if i < -1 || i >= len(r.options) || i == r.selected {
+		return
+	}
+	r.selected = i
+	if r.onSelectionChanged != nil {
+		r.onSelectionChanged(r)
+	}
+	r.rePaint(r)
+}
+
+// OnSelectionChanged sets the function called when the selection changes.
+func (r *RadioGroup) OnSelectionChanged(fn func(*RadioGroup)) {
+	r.onSelectionChanged = fn
+}
+
+func (r *RadioGroup) glyph(i int) string {
+	if i == r.selected {
+		return Theme.Radio.Selected
+	}
+	return Theme.Radio.Unselected
+}
+
+// optionWidth returns the width of option i with its glyph.
+func (r *RadioGroup) optionWidth(i int) int {
+	return stringWidth(r.glyph(i)) + 1 + stringWidth(r.options[i])
+}
+
+// optionRects returns the position of each option relative to the inner
+// rectangle. Options in a row are separated by two spaces.
+func (r *RadioGroup) optionRects() []image.Rectangle {
+	rects := make([]image.Rectangle, len(r.options))
+	var x int
+	for i := range r.options {
+		w := r.optionWidth(i)
+		if r.layout == Horizontal {
+			rects[i] = image.Rect(x, 0, x+w, 1)
+			x += w + 2
+		} else {
+			rects[i] = image.Rect(0, i, w, i+1)
+		}
+	}
+	return rects
+}
+
+// SizeHint returns the size showing every option.
+func (r *RadioGroup) SizeHint() image.Point {
+	var size image.Point
+	for _, rect := range r.optionRects() {
+		size.X = MaxInt(size.X, rect.Max.X)
+		size.Y = MaxInt(size.Y, rect.Max.Y)
+	}
+	return size.Add(r.FrameSize())
+}
+
+// MinSizeHint returns the size showing the glyphs of every option.
+func (r *RadioGroup) MinSizeHint() image.Point {
+	n := len(r.options)
+	w := stringWidth(Theme.Radio.Unselected)
+	if r.layout == Horizontal {
+		return image.Pt(n*w, MinInt(n, 1)).Add(r.FrameSize())
+	}
+	return image.Pt(MinInt(n, 1)*w, n).Add(r.FrameSize())
+}
+
+// Draw draws the options. The selected one is highlighted when the group is
+// focused.
+func (r *RadioGroup) Draw() {
+	r.Lock()
+	defer r.Unlock()
+
+	p := r.GetPainter()
+	if p == nil {
+		return
+	}
+	r.Block.draw()
+	inner := r.GetInnerRealPos()
+	p.PushClip(inner)
+	for i, rect := range r.optionRects() {
+		style := Theme.Radio.Text
+		if i == r.selected && r.IsFocused() {
+			style = Theme.Radio.Focused
+		}
+		p.SetString(r.glyph(i)+" "+r.options[i], style, inner.Min.Add(rect.Min))
+	}
+	// Without a selection, the focus is shown on the first option.
+	if r.selected < 0 && r.IsFocused() && len(r.options) > 0 {
+		p.SetString(r.glyph(0), Theme.Radio.Focused, inner.Min)
+	}
+	p.PopClip()
+}
+
+// Keybindings returns the keys handled by the radio group.
+func (r *RadioGroup) Keybindings() []KeyHelp {
+	prev, next := KeyArrowUp, KeyArrowDown
+	if r.layout == Horizontal {
+		prev, next = KeyArrowLeft, KeyArrowRight
+	}
+	return []KeyHelp{
+		{[]string{prev}, "Select the previous option"},
+		{[]string{next}, "Select the next option"},
+		{[]string{KeyHome, KeyEnd}, "Select the first or last option"},
+		{[]string{KeySpace}, "Select the first option if none is selected"},
+	}
+}
+
+// DoEvent moves the selection with the arrow keys and Home/End when the
+// group is focused, and selects the option that is clicked.
+func (r *RadioGroup) DoEvent(e Event) bool {
+	if len(r.options) == 0 {
+		return false
+	}
+	switch e.Type {
+	case KeyboardEvent:
+		if !r.IsFocused() {
+			return false
+		}
+		return r.doKeyEvent(e)
+	case MouseEvent:
+		pt, ok := clickedAt(r, e)
+		if !ok {
+			return false
+		}
+		pt = pt.Sub(r.GetInnerRealPos().Min)
+		for i, rect := range r.optionRects() {
+			if pt.In(rect) {
+				r.SetSelected(i)
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (r *RadioGroup) doKeyEvent(e Event) bool {
+	prev, next := KeyArrowUp, KeyArrowDown
+	if r.layout == Horizontal {
+		prev, next = KeyArrowLeft, KeyArrowRight
+	}
+	last := len(r.options) - 1
+	switch e.ID {
+	case prev:
+		r.SetSelected(MaxInt(r.selected-1, 0))
+	case next:
+		r.SetSelected(MinInt(r.selected+1, last))
+	case KeyHome:
+		r.SetSelected(0)
+	case KeyEnd:
+		r.SetSelected(last)
+	case KeySpace:
+		if r.selected >= 0 {
+			return false
+		}
+		r.SetSelected(0)
+	default:
+		return false
+	}
+	return true
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mitchellh/go-wordwrap"
 	"image"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -110,6 +111,11 @@ func (r *RuneBuffer) Runes() []rune {
 	return r.buf
 }
 
+// MoveTo moves the cursor to index i, clamped to the buffer.
+func (r *RuneBuffer) MoveTo(i int) {
+	r.idx = MaxInt(0, MinInt(i, len(r.buf)))
+}
+
 // MoveBackward moves the cursor back by one rune.
 func (r *RuneBuffer) MoveBackward() {
 	if r.idx == 0 {
@@ -139,7 +145,7 @@ func (r *RuneBuffer) MoveToLineStart() {
 
 // MoveToLineEnd moves the cursor to the end of the current line.
 func (r *RuneBuffer) MoveToLineEnd() {
-	for i := r.idx; i < len(r.buf)-1; i++ {
+	for i := r.idx; i < len(r.buf); i++ {
 		if r.buf[i] == '\n' {
 			r.idx = i
 			return
@@ -148,6 +154,57 @@ func (r *RuneBuffer) MoveToLineEnd() {
 	r.idx = len(r.buf)
 }
 
+// wordStart returns the index of the start of the word before i, skipping
+// the non-word runes in between.
+func (r *RuneBuffer) wordStart(i int) int {
+	for i > 0 && !isWordRune(r.buf[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(r.buf[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the index of the end of the word after i, skipping the
+// non-word runes in between.
+func (r *RuneBuffer) wordEnd(i int) int {
+	for i < len(r.buf) && !isWordRune(r.buf[i]) {
+		i++
+	}
+	for i < len(r.buf) && isWordRune(r.buf[i]) {
+		i++
+	}
+	return i
+}
+
+// isWordRune reports whether c is part of a word for word-wise movement.
+func isWordRune(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_'
+}
+
+// MoveBackwardWord moves the cursor to the start of the current or previous
+// word.
+func (r *RuneBuffer) MoveBackwardWord() {
+	r.idx = r.wordStart(r.idx)
+}
+
+// MoveForwardWord moves the cursor to the end of the current or next word.
+func (r *RuneBuffer) MoveForwardWord() {
+	r.idx = r.wordEnd(r.idx)
+}
+
+// DeleteRange deletes the runes between from and to and moves the cursor to
+// from. It returns the deleted runes.
+func (r *RuneBuffer) DeleteRange(from, to int) []rune {
+	from = MaxInt(0, MinInt(from, len(r.buf)))
+	to = MaxInt(from, MinInt(to, len(r.buf)))
+	deleted := append([]rune(nil), r.buf[from:to]...)
+	r.buf = append(r.buf[:from], r.buf[to:]...)
+	r.idx = from
+	return deleted
+}
+
 // Backspace deletes the rune left of the cursor.
 func (r *RuneBuffer) Backspace() {
 	if r.idx == 0 {
@@ -165,14 +222,17 @@ func (r *RuneBuffer) Delete() {
 	r.buf = append(r.buf[:r.idx], r.buf[r.idx+1:]...)
 }
 
-// Kill deletes all runes from the cursor until the end of the line.
+// Kill deletes all runes from the cursor until the end of the line. At the
+// end of a line, it deletes the line break instead.
 func (r *RuneBuffer) Kill() {
-	newlineIdx := strings.IndexRune(string(r.buf[r.idx:]), '\n')
-	if newlineIdx < 0 {
-		r.buf = r.buf[:r.idx]
-	} else {
-		r.buf = append(r.buf[:r.idx], r.buf[r.idx+newlineIdx+1:]...)
+	end := r.idx
+	for end < len(r.buf) && r.buf[end] != '\n' {
+		end++
+	}
+	if end == r.idx && end < len(r.buf) {
+		end++
 	}
+	r.buf = append(r.buf[:r.idx], r.buf[end:]...)
 }
 
 func (r *RuneBuffer) heightForWidth(w int) int {
@@ -88,11 +88,11 @@ func (self *Screen) SetString(s string, style Style, p image.Point) {
 }
 
 func (s *Screen) SetCursor(x, y int) {
-
+	tb.SetCursor(x, y)
 }
 
 func (s *Screen) HideCursor() {
-
+	tb.HideCursor()
 }
 
 func (s *Screen) Clear() {
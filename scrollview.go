@@ -0,0 +1,323 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+var _ Widget = &ScrollView{}
+
+const scrollWheelStep = 3
+
+// scrollDrag is the scrollbar being dragged with the mouse.
+type scrollDrag int
+
+const (
+	dragNone scrollDrag = iota
+	dragVertical
+	dragHorizontal
+)
+
+// ScrollView shows a viewport onto a widget that may be larger than the
+// ScrollView. The widget gets its size hint along the scrolling axes, and
+// scrollbars are shown when it doesn't fit. Moving the focus with Tab to a
+// widget inside the ScrollView scrolls it into view.
+type ScrollView struct {
+	Block
+	child Widget
+
+	offset   image.Point
+	content  image.Point
+	viewport image.Point
+
+	horizontal, vertical bool
+	showH, showV         bool
+
+	drag scrollDrag
+}
+
+// NewScrollView returns a new ScrollView scrolling w in both directions.
+func NewScrollView(w Widget) *ScrollView {
+	s := &ScrollView{
+		Block:      *NewBlock(),
+		child:      w,
+		horizontal: true,
+		vertical:   true,
+	}
+	s.Append(w)
+	return s
+}
+
+// SetScrollAxes sets the directions the ScrollView scrolls in. Along a
+// direction that doesn't scroll the widget gets the size of the viewport.
+func (s *ScrollView) SetScrollAxes(horizontal, vertical bool) {
+	s.horizontal = horizontal
+	s.vertical = vertical
+	s.ReLayout()
+}
+
+// Offset returns the position in the widget shown at the top left corner of
+// the viewport.
+func (s *ScrollView) Offset() image.Point {
+	return s.offset
+}
+
+// ScrollTo shows the widget from the given position.
+func (s *ScrollView) ScrollTo(x, y int) {
+	max := s.content.Sub(s.viewport)
+	s.offset.X = MaxInt(0, MinInt(x, max.X))
+	s.offset.Y = MaxInt(0, MinInt(y, max.Y))
+	s.child.Resize(s.offset.Mul(-1), s.content)
+}
+
+// ScrollBy moves the viewport by dx and dy cells.
+func (s *ScrollView) ScrollBy(dx, dy int) {
+	s.ScrollTo(s.offset.X+dx, s.offset.Y+dy)
+}
+
+// ScrollIntoView scrolls as little as needed to show r, given in the
+// coordinates of the widget.
+func (s *ScrollView) ScrollIntoView(r image.Rectangle) {
+	off := s.offset
+	if r.Max.X > off.X+s.viewport.X {
+		off.X = r.Max.X - s.viewport.X
+	}
+	if r.Min.X < off.X {
+		off.X = r.Min.X
+	}
+	if r.Max.Y > off.Y+s.viewport.Y {
+		off.Y = r.Max.Y - s.viewport.Y
+	}
+	if r.Min.Y < off.Y {
+		off.Y = r.Min.Y
+	}
+	if off != s.offset {
+		s.ScrollTo(off.X, off.Y)
+	}
+}
+
+// showDescendant scrolls as little as needed to show w, a widget inside the
+// ScrollView.
+func (s *ScrollView) showDescendant(w Widget) {
+	origin := s.GetInnerRealPos().Min.Sub(s.offset)
+	s.ScrollIntoView(realOuter(w).Sub(origin))
+}
+
+// Resize updates the size of the ScrollView and lays out the widget at its
+// virtual size.
+func (s *ScrollView) Resize(pos image.Point, size image.Point) {
+	if !s.needsLayout(pos, size) {
+		return
+	}
+	s.SetRect(pos.X, pos.Y, size.X, size.Y)
+	s.ReLayout()
+}
+
+// ReLayout computes the virtual size of the widget and which scrollbars are
+// needed, and lays out the widget.
+func (s *ScrollView) ReLayout() {
+	inner := s.GetInner().Size()
+	hint := sizeHintOf(s.child)
+
+	content := func(vp image.Point) image.Point {
+		c := vp
+		if s.horizontal && hint.X > c.X {
+			c.X = hint.X
+		}
+		if s.vertical && hint.Y > c.Y {
+			c.Y = hint.Y
+		}
+		return c
+	}
+
+	vp := inner
+	s.showH, s.showV = false, false
+	if content(vp).Y > vp.Y {
+		s.showV = true
+		vp.X--
+	}
+	if content(vp).X > vp.X {
+		s.showH = true
+		vp.Y--
+		if !s.showV && content(vp).Y > vp.Y {
+			s.showV = true
+			vp.X--
+		}
+	}
+	s.viewport = image.Pt(MaxInt(vp.X, 0), MaxInt(vp.Y, 0))
+	s.content = content(s.viewport)
+	s.ScrollTo(s.offset.X, s.offset.Y)
+}
+
+// SizeHint returns the size hint of the widget.
+func (s *ScrollView) SizeHint() image.Point {
+	return sizeHintOf(s.child).Add(s.FrameSize())
+}
+
+// MinSizeHint returns the minimum size, which is enough for the scrollbars.
+func (s *ScrollView) MinSizeHint() image.Point {
+	return image.Pt(2, 2).Add(s.FrameSize())
+}
+
+// Draw draws the visible part of the widget and the scrollbars.
+func (s *ScrollView) Draw() {
+	s.Lock()
+	defer s.Unlock()
+
+	p := s.GetPainter()
+	if p == nil {
+		return
+	}
+	s.Block.draw()
+
+	inner := s.GetInnerRealPos()
+	p.PushClip(image.Rectangle{inner.Min, inner.Min.Add(s.viewport)})
+	s.child.Draw()
+	p.PopClip()
+
+	if s.showV {
+		s.drawScrollBar(p, true, image.Rect(inner.Max.X-1, inner.Min.Y, inner.Max.X, inner.Min.Y+s.viewport.Y))
+	}
+	if s.showH {
+		s.drawScrollBar(p, false, image.Rect(inner.Min.X, inner.Max.Y-1, inner.Min.X+s.viewport.X, inner.Max.Y))
+	}
+}
+
+// thumb returns the offset and length of the scrollbar thumb along a track of
+// the given length.
+func thumb(track, offset, viewport, content int) (int, int) {
+	if content <= viewport || track <= 0 {
+		return 0, track
+	}
+	length := MaxInt(1, track*viewport/content)
+	pos := offset * (track - length) / (content - viewport)
+	return pos, length
+}
+
+func (s *ScrollView) drawScrollBar(p *Painter, vertical bool, r image.Rectangle) {
+	track := Cell{Theme.ScrollBar.Track, Theme.ScrollBar.Style}
+	p.Fill(track, r)
+
+	var pos, length int
+	if vertical {
+		pos, length = thumb(r.Dy(), s.offset.Y, s.viewport.Y, s.content.Y)
+		r = image.Rect(r.Min.X, r.Min.Y+pos, r.Max.X, r.Min.Y+pos+length)
+	} else {
+		pos, length = thumb(r.Dx(), s.offset.X, s.viewport.X, s.content.X)
+		r = image.Rect(r.Min.X+pos, r.Min.Y, r.Min.X+pos+length, r.Max.Y)
+	}
+	p.Fill(Cell{Theme.ScrollBar.Thumb, Theme.ScrollBar.Style}, r)
+}
+
+// DoEvent passes events to the widget first. Unhandled scroll keys scroll
+// the view when the ScrollView is focused; the mouse wheel scrolls it when
+// the pointer is over it, and the scrollbars can be dragged.
+func (s *ScrollView) DoEvent(e Event) bool {
+	switch e.Type {
+	case KeyboardEvent:
+		if s.child.DoEvent(e) {
+			s.rePaint(s)
+			return true
+		}
+		if !s.IsFocused() {
+			return false
+		}
+		if !s.doKeyEvent(e) {
+			return false
+		}
+	case MouseEvent:
+		if !s.doMouseEvent(e) {
+			return false
+		}
+	case ResizeEvent:
+		return s.WidgetBase.DoEvent(e)
+	default:
+		return false
+	}
+	s.rePaint(s)
+	return true
+}
+
+func (s *ScrollView) doKeyEvent(e Event) bool {
+	switch e.ID {
+	case KeyArrowUp:
+		s.ScrollBy(0, -1)
+	case KeyArrowDown:
+		s.ScrollBy(0, 1)
+	case KeyArrowLeft:
+		s.ScrollBy(-1, 0)
+	case KeyArrowRight:
+		s.ScrollBy(1, 0)
+	case KeyPgup:
+		s.ScrollBy(0, -s.viewport.Y)
+	case KeyPgdn:
+		s.ScrollBy(0, s.viewport.Y)
+	case KeyHome:
+		s.ScrollTo(s.offset.X, 0)
+	case KeyEnd:
+		s.ScrollTo(s.offset.X, s.content.Y)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *ScrollView) doMouseEvent(e Event) bool {
+	m := e.Payload.(Mouse)
+	pt := image.Pt(m.X, m.Y)
+	inner := s.GetInnerRealPos()
+
+	if s.drag != dragNone {
+		switch {
+		case e.ID == "<MouseRelease>":
+			s.drag = dragNone
+		case s.drag == dragVertical:
+			s.dragTo(pt.Y-inner.Min.Y, s.viewport.Y, true)
+		default:
+			s.dragTo(pt.X-inner.Min.X, s.viewport.X, false)
+		}
+		return true
+	}
+	if !pt.In(realOuter(s)) {
+		return false
+	}
+
+	switch e.ID {
+	case "<MouseWheelUp>":
+		s.ScrollBy(0, -scrollWheelStep)
+		return true
+	case "<MouseWheelDown>":
+		s.ScrollBy(0, scrollWheelStep)
+		return true
+	case "<MouseLeft>":
+		if s.showV && pt.X == inner.Max.X-1 && pt.Y < inner.Min.Y+s.viewport.Y {
+			s.drag = dragVertical
+			s.dragTo(pt.Y-inner.Min.Y, s.viewport.Y, true)
+			return true
+		}
+		if s.showH && pt.Y == inner.Max.Y-1 && pt.X < inner.Min.X+s.viewport.X {
+			s.drag = dragHorizontal
+			s.dragTo(pt.X-inner.Min.X, s.viewport.X, false)
+			return true
+		}
+	}
+	if pt.In(image.Rectangle{inner.Min, inner.Min.Add(s.viewport)}) {
+		return s.child.DoEvent(e)
+	}
+	return true
+}
+
+// dragTo scrolls so that the thumb is at position pos of a track of the
+// given length.
+func (s *ScrollView) dragTo(pos, track int, vertical bool) {
+	if track <= 1 {
+		return
+	}
+	pos = MaxInt(0, MinInt(pos, track-1))
+	if vertical {
+		s.ScrollTo(s.offset.X, pos*(s.content.Y-s.viewport.Y)/(track-1))
+	} else {
+		s.ScrollTo(pos*(s.content.X-s.viewport.X)/(track-1), s.offset.Y)
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"strings"
+	"time"
+)
+
+var _ Widget = &Select{}
+
+// typeAheadDelay is how long a Select waits for the next key before a new
+// type-ahead search starts.
+const typeAheadDelay = time.Second
+
+// Select shows a value chosen from a list of items. Enter, Space or a click
+// opens the list below it, where typing filters the items. While the list is
+// closed, the arrow keys step through the items and typing jumps to the
+// first item starting with the typed text.
+//
+// In combo mode, any text can be typed in as the value, and the list shows
+// the items containing it.
+type Select struct {
+	Input
+
+	items []string
+	// value is the chosen value, one of the items unless in combo mode.
+	value string
+	combo bool
+
+	open bool
+	list popupList
+	// shown holds the indices of the items in the list.
+	shown []int
+
+	typed   string
+	typedAt time.Time
+
+	onValueChanged func(*Select)
+}
+
+// NewSelect returns a new Select with the given items, the first one chosen.
+func NewSelect(items ...string) *Select {
+	s := &Select{
+		Input: *NewInput(),
+	}
+	s.reserved = stringWidth(Theme.Select.Arrow) + 1
+	s.SetItems(items...)
+	return s
+}
+
+// SetItems sets the items to choose from. Unless in combo mode, the value
+// becomes the first item if it isn't one of them.
+func (s *Select) SetItems(items ...string) {
+	s.Lock()
+	defer s.Unlock()
+	s.items = items
+	s.close()
+	if !s.combo && s.Selected() < 0 {
+		s.value = ""
+		if len(items) > 0 {
+			s.value = items[0]
+		}
+	}
+	s.setText(s.value)
+	s.rePaint(s)
+}
+
+// Items returns the items to choose from.
+func (s *Select) Items() []string {
+	return s.items
+}
+
+// SetCombo sets whether any text can be typed in as the value.
+func (s *Select) SetCombo(enabled bool) {
+	s.combo = enabled
+}
+
+// Value returns the chosen value.
+func (s *Select) Value() string {
+	return s.value
+}
+
+// SetValue sets the value. Unless in combo mode, values other than the items
+// are ignored.
+func (s *Select) SetValue(v string) {
+	if !s.combo && s.indexOf(v) < 0 {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.value = v
+	s.setText(v)
+	s.rePaint(s)
+}
+
+// Selected returns the index of the chosen item, or -1 if the value isn't
+// one of the items.
+func (s *Select) Selected() int {
+	return s.indexOf(s.value)
+}
+
+// SetSelected chooses the item at index i.
+func (s *Select) SetSelected(i int) {
+	if i >= 0 && i < len(s.items) {
+		s.SetValue(s.items[i])
+	}
+}
+
+// OnValueChanged sets a function to be run when the user changes the value.
+func (s *Select) OnValueChanged(fn func(*Select)) {
+	s.onValueChanged = fn
+}
+
+// SetFocused focuses the Select. The list closes when it loses the focus.
+func (s *Select) SetFocused(f bool) {
+	s.Input.SetFocused(f)
+	if !f && s.open {
+		s.cancel()
+	}
+}
+
+func (s *Select) indexOf(v string) int {
+	for i, item := range s.items {
+		if item == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// choose makes v the value and closes the list.
+func (s *Select) choose(v string) {
+	s.close()
+	s.setText(v)
+	if v == s.value {
+		return
+	}
+	s.value = v
+	if s.onValueChanged != nil {
+		s.onValueChanged(s)
+	}
+}
+
+// openList opens the list with all items, the chosen one selected. The text
+// is selected, so that typing replaces it with a filter.
+func (s *Select) openList() {
+	s.open = true
+	s.filter("")
+	s.list.selected = s.Selected()
+	s.text.MoveToLineEnd()
+	s.anchor = 0
+}
+
+// filter shows the items containing text, ignoring case, and selects the
+// first one.
+func (s *Select) filter(text string) {
+	text = strings.ToLower(text)
+	s.shown = s.shown[:0]
+	var items []string
+	for i, item := range s.items {
+		if strings.Contains(strings.ToLower(item), text) {
+			s.shown = append(s.shown, i)
+			items = append(items, item)
+		}
+	}
+	selected := -1
+	if len(items) > 0 {
+		selected = 0
+	}
+	s.list.set(items, selected)
+}
+
+func (s *Select) close() {
+	s.open = false
+	s.list.clear()
+}
+
+// cancel closes the list and restores the text of the value.
+func (s *Select) cancel() {
+	s.close()
+	s.setText(s.value)
+}
+
+// accept chooses the item selected in the list, or in combo mode the text
+// when no item is selected.
+func (s *Select) accept() {
+	switch {
+	case s.list.selected >= 0:
+		s.choose(s.items[s.shown[s.list.selected]])
+	case s.combo:
+		s.choose(s.Text())
+	default:
+		s.cancel()
+	}
+}
+
+// typeAhead chooses the next item starting with the keys typed in quick
+// succession.
+func (s *Select) typeAhead(key string) {
+	now := time.Now()
+	if now.Sub(s.typedAt) > typeAheadDelay {
+		s.typed = ""
+	}
+	s.typedAt = now
+	s.typed += strings.ToLower(key)
+
+	n := len(s.items)
+	start := s.Selected()
+	if len([]rune(s.typed)) == 1 {
+		// A new search starts past the chosen item, so that typing the same
+		// letter again goes through the items starting with it.
+		start++
+	}
+	for i := 0; i < n; i++ {
+		j := ((start+i)%n + n) % n
+		if strings.HasPrefix(strings.ToLower(s.items[j]), s.typed) {
+			s.choose(s.items[j])
+			return
+		}
+	}
+}
+
+// Keybindings returns the keys handled by the Select.
+func (s *Select) Keybindings() []KeyHelp {
+	keys := []KeyHelp{
+		{[]string{KeyEnter, KeySpace}, "Open the list, or choose the selected item"},
+		{[]string{KeyArrowUp, KeyArrowDown}, "Choose the previous or next item"},
+		{[]string{KeyPgup, KeyPgdn}, "Move up or down one page in the list"},
+		{[]string{KeyEsc}, "Close the list"},
+	}
+	if s.combo {
+		keys = append(keys, s.Input.Keybindings()...)
+	}
+	return keys
+}
+
+// DoEvent opens, closes and navigates the list. Keys that edit the text are
+// left to the Input in combo mode, or while the list is open to filter it.
+func (s *Select) DoEvent(e Event) bool {
+	var handled bool
+	switch e.Type {
+	case KeyboardEvent:
+		if !s.IsFocused() {
+			return false
+		}
+		if s.open {
+			handled = s.doListKey(e)
+		} else {
+			handled = s.doKey(e)
+		}
+	case MouseEvent:
+		handled = s.doMouseEvent(e)
+	}
+	if handled {
+		s.rePaint(s)
+	}
+	return handled
+}
+
+// doKey handles a key while the list is closed.
+func (s *Select) doKey(e Event) bool {
+	switch e.ID {
+	case KeyArrowUp:
+		s.chooseItem(s.Selected() - 1)
+		return true
+	case KeyArrowDown:
+		if s.combo {
+			s.openList()
+		} else {
+			s.chooseItem(s.Selected() + 1)
+		}
+		return true
+	case KeyEnter:
+		if s.combo {
+			s.choose(s.Text())
+			return s.Input.DoKeyEvent(e)
+		}
+		s.openList()
+		return true
+	case KeySpace:
+		if !s.combo {
+			s.openList()
+			return true
+		}
+	}
+	if !s.combo {
+		if isCharKey(e.ID) {
+			s.typeAhead(e.ID)
+			return true
+		}
+		return false
+	}
+	return s.editText(e)
+}
+
+// doListKey handles a key while the list is open.
+func (s *Select) doListKey(e Event) bool {
+	switch e.ID {
+	case KeyEsc:
+		s.cancel()
+	case KeyEnter:
+		s.accept()
+	case KeyArrowUp:
+		s.list.move(-1, false)
+	case KeyArrowDown:
+		s.list.move(1, false)
+	case KeyPgup:
+		s.list.move(-popupRows, false)
+	case KeyPgdn:
+		s.list.move(popupRows, false)
+	case KeyTab:
+		// Tab moves the focus, which closes the list.
+		return false
+	default:
+		return s.editText(e)
+	}
+	return true
+}
+
+// editText lets the Input handle e, and filters the list by the new text. In
+// combo mode, the list opens when typing changes the text.
+func (s *Select) editText(e Event) bool {
+	before := s.Text()
+	if !s.Input.DoKeyEvent(e) {
+		return false
+	}
+	if s.Text() != before {
+		s.open = true
+		s.filter(s.Text())
+	}
+	return true
+}
+
+// chooseItem chooses the item at index i, limited to the items.
+func (s *Select) chooseItem(i int) {
+	if len(s.items) == 0 {
+		return
+	}
+	s.choose(s.items[MaxInt(0, MinInt(i, len(s.items)-1))])
+}
+
+// doMouseEvent chooses the clicked item of the list and opens or closes the
+// list when the Select is clicked. The wheel moves through the list.
+func (s *Select) doMouseEvent(e Event) bool {
+	m := e.Payload.(Mouse)
+	pt := image.Pt(m.X, m.Y)
+	switch e.ID {
+	case "<MouseLeft>":
+		if m.Drag {
+			return false
+		}
+		if i := s.list.itemAt(pt); s.open && i >= 0 {
+			s.list.selected = i
+			s.accept()
+			return true
+		}
+		if !pt.In(realOuter(s)) {
+			if s.open {
+				s.cancel()
+				s.rePaint(s)
+			}
+			return false
+		}
+		inner := s.GetInnerRealPos()
+		if s.combo && pt.X < inner.Max.X-s.reserved {
+			s.close()
+			return s.Input.DoEvent(e)
+		}
+		if s.open {
+			s.cancel()
+		} else {
+			s.openList()
+		}
+		return true
+	case "<MouseWheelUp>", "<MouseWheelDown>":
+		if !s.open || !pt.In(s.list.rect) {
+			return false
+		}
+		n := 1
+		if e.ID == "<MouseWheelUp>" {
+			n = -1
+		}
+		s.list.move(n, false)
+		return true
+	}
+	return s.Input.DoEvent(e)
+}
+
+// Draw draws the value with an arrow, and the list when it is open.
+func (s *Select) Draw() {
+	s.Lock()
+	defer s.Unlock()
+	s.draw()
+	p := s.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := s.GetInnerRealPos()
+	arrow := image.Pt(inner.Max.X-stringWidth(Theme.Select.Arrow), inner.Min.Y)
+	p.PushClip(inner)
+	p.SetString(Theme.Select.Arrow, Theme.Input.Text, arrow)
+	p.PopClip()
+
+	if s.open && s.IsFocused() && len(s.list.items) > 0 {
+		p.DrawPopup(func() {
+			s.Lock()
+			defer s.Unlock()
+			outer := realOuter(s)
+			s.list.draw(p, outer, outer.Min.X, outer.Dx(), Theme.Select.Item, Theme.Select.Selected)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package termuix
+
+import (
+	"os"
+	"syscall"
+)
+
+// resizeSignals are the OS signals that report a terminal resize.
+var resizeSignals = []os.Signal{syscall.SIGWINCH}
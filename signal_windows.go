@@ -0,0 +1,13 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+//go:build windows
+// +build windows
+
+package termuix
+
+import "os"
+
+// resizeSignals is empty on Windows, which has no resize signal.
+var resizeSignals []os.Signal
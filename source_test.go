@@ -0,0 +1,76 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix_test
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"testing"
+
+	uix "github.com/thzll/termuix"
+	"github.com/thzll/termuix/widgets"
+)
+
+// TestListSourceAppendedConcurrently appends to the source of a List from
+// another goroutine while the List handles keys, as a log viewer does. Run
+// with -race to check that the List is only changed on the UI goroutine.
+func TestListSourceAppendedConcurrently(t *testing.T) {
+	src := widgets.NewStreamSource()
+	l := widgets.NewList()
+	p := uix.NewPainter()
+	l.SetPainter(p)
+	l.SetSource(src)
+	l.SetFocused(true)
+	l.Resize(image.Point{}, image.Pt(20, 5))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			src.AppendLine(fmt.Sprint(i))
+		}
+	}()
+	down := uix.Event{Type: uix.KeyboardEvent, ID: uix.KeyArrowDown}
+	for i := 0; i < 200; i++ {
+		l.DoEvent(down)
+		uix.DrainPosted(p)
+	}
+	wg.Wait()
+	uix.DrainPosted(p)
+	if l.SelectedRow != 499 {
+		t.Errorf("SelectedRow = %d, want the last row 499", l.SelectedRow)
+	}
+}
+
+// TestListSourceAppendedOnUIGoroutine appends many rows from the UI
+// goroutine, which must neither block on repaint requests nor update the List
+// once per row.
+func TestListSourceAppendedOnUIGoroutine(t *testing.T) {
+	src := widgets.NewStreamSource()
+	l := widgets.NewList()
+	p := uix.NewPainter()
+	l.SetPainter(p)
+	l.SetSource(src)
+	l.Resize(image.Point{}, image.Pt(20, 5))
+
+	for i := 0; i < 1000; i++ {
+		src.AppendLine(fmt.Sprint(i))
+	}
+	if n := uix.DrainPosted(p); n != 1 {
+		t.Errorf("1000 appends posted %d updates, want 1", n)
+	}
+	if l.SelectedRow != 999 {
+		t.Errorf("SelectedRow = %d, want the last row 999", l.SelectedRow)
+	}
+
+	src.Set([]string{"a"}, []string{"b"})
+	src.AppendLine("c")
+	uix.DrainPosted(p)
+	if l.SelectedRow != 2 {
+		t.Errorf("after resetting the rows SelectedRow = %d, want 2", l.SelectedRow)
+	}
+}
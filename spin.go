@@ -0,0 +1,148 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var _ Widget = &SpinInput{}
+
+// SpinInput is an Input for a number between a minimum and a maximum. Only
+// numbers can be typed, and the arrow keys and the mouse wheel step the value
+// up and down.
+type SpinInput struct {
+	Input
+
+	min, max, step float64
+	// decimals is the number of decimals of step, used to format the value.
+	decimals int
+}
+
+// NewSpinInput returns a new SpinInput for numbers from min to max, stepping
+// by step, set to min.
+func NewSpinInput(min, max, step float64) *SpinInput {
+	s := &SpinInput{
+		Input: *NewInput(),
+	}
+	s.SetRange(min, max, step)
+	s.SetValue(min)
+	return s
+}
+
+// SetRange sets the minimum and maximum values, and the step of the arrow
+// keys. The value is formatted with as many decimals as step has.
+func (s *SpinInput) SetRange(min, max, step float64) {
+	s.min, s.max, s.step = min, max, step
+	s.decimals = 0
+	str := strconv.FormatFloat(step, 'f', -1, 64)
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		s.decimals = len(str) - i - 1
+	}
+	s.SetMask(s.acceptNumber)
+	s.SetValidator(s.validateNumber)
+}
+
+// Value returns the value of the SpinInput, limited to its range. Text that
+// isn't a number has the minimum value.
+func (s *SpinInput) Value() float64 {
+	v, err := strconv.ParseFloat(s.Text(), 64)
+	if err != nil {
+		return s.min
+	}
+	return s.clamp(v)
+}
+
+// SetValue sets the value of the SpinInput, limited to its range.
+func (s *SpinInput) SetValue(v float64) {
+	s.SetText(s.format(s.clamp(v)))
+}
+
+func (s *SpinInput) clamp(v float64) float64 {
+	return math.Max(s.min, math.Min(v, s.max))
+}
+
+func (s *SpinInput) format(v float64) string {
+	return strconv.FormatFloat(v, 'f', s.decimals, 64)
+}
+
+// acceptNumber is the Mask of the SpinInput. It only accepts a minus sign if
+// negative values are allowed, and a decimal point if the step has decimals.
+func (s *SpinInput) acceptNumber(text string) bool {
+	if s.min < 0 {
+		text = strings.TrimPrefix(text, "-")
+	}
+	if s.decimals > 0 {
+		if i := strings.IndexByte(text, '.'); i >= 0 {
+			text = text[:i] + text[i+1:]
+		}
+	}
+	return MaskDigits(text)
+}
+
+func (s *SpinInput) validateNumber(text string) error {
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil || v < s.min || v > s.max {
+		return fmt.Errorf("must be a number from %s to %s", s.format(s.min), s.format(s.max))
+	}
+	return nil
+}
+
+// stepBy adds n steps to the value.
+func (s *SpinInput) stepBy(n int) {
+	v := s.format(s.clamp(s.Value() + float64(n)*s.step))
+	s.edit(editOther, func() { s.text.Set([]rune(v)) })
+}
+
+// Keybindings returns the keys handled by the SpinInput.
+func (s *SpinInput) Keybindings() []KeyHelp {
+	return append([]KeyHelp{
+		{[]string{KeyArrowUp, KeyArrowDown}, "Step the value up or down"},
+		{[]string{KeyPgup, KeyPgdn}, "Step the value up or down by ten steps"},
+	}, s.Input.Keybindings()...)
+}
+
+// DoEvent steps the value with the arrow keys and the mouse wheel, and edits
+// it like an Input otherwise.
+func (s *SpinInput) DoEvent(e Event) bool {
+	n := 0
+	switch e.Type {
+	case KeyboardEvent:
+		if !s.IsFocused() {
+			return false
+		}
+		switch e.ID {
+		case KeyArrowUp:
+			n = 1
+		case KeyArrowDown:
+			n = -1
+		case KeyPgup:
+			n = 10
+		case KeyPgdn:
+			n = -10
+		}
+	case MouseEvent:
+		if e.ID == "<MouseWheelUp>" || e.ID == "<MouseWheelDown>" {
+			m := e.Payload.(Mouse)
+			if !image.Pt(m.X, m.Y).In(realOuter(s)) {
+				return false
+			}
+			n = 1
+			if e.ID == "<MouseWheelDown>" {
+				n = -1
+			}
+		}
+	}
+	if n == 0 {
+		return s.Input.DoEvent(e)
+	}
+	s.stepBy(n)
+	s.rePaint(s)
+	return true
+}
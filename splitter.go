@@ -0,0 +1,522 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"time"
+)
+
+var _ Widget = &Splitter{}
+
+// doubleClickTime is the longest time between two clicks of a double-click.
+const doubleClickTime = 400 * time.Millisecond
+
+// splitPane holds the layout state of a pane of a Splitter.
+type splitPane struct {
+	ratio float64
+	min   int
+	// saved is the ratio of a collapsed pane before it was collapsed.
+	saved     float64
+	collapsed bool
+}
+
+// Splitter lays out its panes side by side (Horizontal) or on top of each
+// other (Vertical), separated by dividers that can be moved with the mouse or,
+// when the Splitter is focused, with the keyboard. The size of each pane is
+// kept as a share of the Splitter, so it survives a resize.
+type Splitter struct {
+	Block
+	panes []splitPane
+	sizes []int
+
+	// active is the divider moved by the keyboard.
+	active int
+	// drag is the divider being dragged, or -1.
+	drag      int
+	lastClick time.Time
+	lastHit   int
+}
+
+// NewHSplitter returns a Splitter with its panes side by side.
+func NewHSplitter(ws ...Widget) *Splitter {
+	return newSplitter(Horizontal, ws)
+}
+
+// NewVSplitter returns a Splitter with its panes on top of each other.
+func NewVSplitter(ws ...Widget) *Splitter {
+	return newSplitter(Vertical, ws)
+}
+
+func newSplitter(l LayoutMode, ws []Widget) *Splitter {
+	s := &Splitter{
+		Block:   *NewBlock(),
+		drag:    -1,
+		lastHit: -1,
+	}
+	s.Border = false
+	s.layout = l
+	s.style = Theme.Splitter.Divider
+	for _, w := range ws {
+		s.Append(w)
+	}
+	return s
+}
+
+// Append adds a pane after the others. The new pane gets an equal share of
+// the Splitter, taken from the other panes in proportion to their size.
+func (s *Splitter) Append(w Widget) {
+	s.Insert(len(s.panes), w)
+}
+
+// Prepend adds a pane before the others, like Append.
+func (s *Splitter) Prepend(w Widget) {
+	s.Insert(0, w)
+}
+
+// Insert adds a pane at index i, like Append.
+func (s *Splitter) Insert(i int, w Widget) {
+	if i < 0 || i > len(s.panes) {
+		return
+	}
+	n := float64(len(s.panes) + 1)
+	for j := range s.panes {
+		s.panes[j].ratio *= (n - 1) / n
+		s.panes[j].saved *= (n - 1) / n
+	}
+	s.panes = append(s.panes, splitPane{})
+	copy(s.panes[i+1:], s.panes[i:])
+	s.panes[i] = splitPane{ratio: 1 / n}
+	s.WidgetBase.Insert(i, w)
+	if i <= s.active && len(s.panes) > 2 {
+		s.active++
+	}
+	s.drag = -1
+	s.ReLayout()
+}
+
+// Remove deletes the pane at index i. Its share goes to the other panes.
+func (s *Splitter) Remove(i int) {
+	if i < 0 || i >= len(s.panes) {
+		return
+	}
+	s.panes = append(s.panes[:i], s.panes[i+1:]...)
+	s.WidgetBase.Remove(i)
+	s.normalize()
+	if s.active >= len(s.panes)-1 {
+		s.active = MaxInt(0, len(s.panes)-2)
+	}
+	s.drag = -1
+	s.ReLayout()
+}
+
+// SetRatios sets the share of the Splitter each pane gets. The ratios are
+// relative to each other; missing ones count as zero.
+func (s *Splitter) SetRatios(ratios ...float64) {
+	for i := range s.panes {
+		s.panes[i].ratio = 0
+		s.panes[i].collapsed = false
+		if i < len(ratios) && ratios[i] > 0 {
+			s.panes[i].ratio = ratios[i]
+		}
+	}
+	s.normalize()
+	s.ReLayout()
+}
+
+// Ratios returns the share of the Splitter each pane gets, adding up to 1.
+func (s *Splitter) Ratios() []float64 {
+	ratios := make([]float64, len(s.panes))
+	for i, p := range s.panes {
+		ratios[i] = p.ratio
+	}
+	return ratios
+}
+
+// SetMinSize sets the size below which the pane at index i isn't shrunk,
+// unless it is collapsed.
+func (s *Splitter) SetMinSize(i, min int) {
+	if i >= 0 && i < len(s.panes) {
+		s.panes[i].min = min
+		s.Invalidate()
+		s.ReLayout()
+	}
+}
+
+// Collapse shrinks the pane at index i to nothing. Its share goes to the
+// closest pane that isn't collapsed.
+func (s *Splitter) Collapse(i int) {
+	if i < 0 || i >= len(s.panes) || s.panes[i].collapsed || len(s.panes) < 2 {
+		return
+	}
+	j := s.neighbour(i)
+	if j < 0 {
+		return
+	}
+	p := &s.panes[i]
+	s.panes[j].ratio += p.ratio
+	p.saved, p.ratio, p.collapsed = p.ratio, 0, true
+	s.ReLayout()
+}
+
+// neighbour returns the closest pane to i that isn't collapsed, looking
+// forward first, or -1.
+func (s *Splitter) neighbour(i int) int {
+	for j := i + 1; j < len(s.panes); j++ {
+		if !s.panes[j].collapsed {
+			return j
+		}
+	}
+	for j := i - 1; j >= 0; j-- {
+		if !s.panes[j].collapsed {
+			return j
+		}
+	}
+	return -1
+}
+
+// Expand restores the pane at index i to its size before it was collapsed.
+func (s *Splitter) Expand(i int) {
+	if i < 0 || i >= len(s.panes) || !s.panes[i].collapsed {
+		return
+	}
+	p := &s.panes[i]
+	for j := range s.panes {
+		if j != i {
+			s.panes[j].ratio *= 1 - p.saved
+		}
+	}
+	p.ratio, p.collapsed = p.saved, false
+	s.normalize()
+	s.ReLayout()
+}
+
+// IsCollapsed reports whether the pane at index i is collapsed.
+func (s *Splitter) IsCollapsed(i int) bool {
+	return i >= 0 && i < len(s.panes) && s.panes[i].collapsed
+}
+
+// normalize scales the ratios to add up to 1, sharing equally if they are
+// all zero.
+func (s *Splitter) normalize() {
+	var sum float64
+	for _, p := range s.panes {
+		sum += p.ratio
+	}
+	for i := range s.panes {
+		if sum > 0 {
+			s.panes[i].ratio /= sum
+		} else if !s.panes[i].collapsed {
+			s.panes[i].ratio = 1 / float64(len(s.panes))
+		}
+	}
+}
+
+// Resize updates the size of the Splitter and lays out its panes.
+func (s *Splitter) Resize(pos image.Point, size image.Point) {
+	if !s.needsLayout(pos, size) {
+		return
+	}
+	s.SetRect(pos.X, pos.Y, size.X, size.Y)
+	s.ReLayout()
+}
+
+// length returns the size of pt along the layout direction.
+func (s *Splitter) length(pt image.Point) int {
+	if s.layout == Horizontal {
+		return pt.X
+	}
+	return pt.Y
+}
+
+// available returns the space left for the panes after the dividers.
+func (s *Splitter) available() int {
+	return MaxInt(0, s.length(s.GetInner().Size())-MaxInt(0, len(s.panes)-1))
+}
+
+// solve shares total between the panes by their ratio, keeping each pane
+// at least at its minimum size as long as there is room.
+func (s *Splitter) solve(total int) []int {
+	n := len(s.panes)
+	sizes := make([]int, n)
+	fixed := make([]bool, n)
+	for {
+		rest := total
+		weights := make([]float64, n)
+		for i, p := range s.panes {
+			if fixed[i] {
+				rest -= sizes[i]
+			} else if !p.collapsed {
+				weights[i] = p.ratio
+			}
+		}
+		parts := distribute(MaxInt(rest, 0), weights)
+		changed := false
+		for i, p := range s.panes {
+			if fixed[i] || p.collapsed {
+				continue
+			}
+			sizes[i] = parts[i]
+			if sizes[i] < p.min && rest > 0 {
+				sizes[i] = MinInt(p.min, rest)
+				fixed[i] = true
+				changed = true
+			}
+		}
+		if !changed {
+			return sizes
+		}
+	}
+}
+
+// ReLayout places the panes and dividers.
+func (s *Splitter) ReLayout() {
+	s.sizes = s.solve(s.available())
+	size := s.GetInner().Size()
+	pos := 0
+	for i, w := range s.children {
+		if s.layout == Horizontal {
+			w.Resize(image.Pt(pos, 0), image.Pt(s.sizes[i], size.Y))
+		} else {
+			w.Resize(image.Pt(0, pos), image.Pt(size.X, s.sizes[i]))
+		}
+		pos += s.sizes[i] + 1
+	}
+}
+
+// dividerAt returns the position of divider i relative to the inner area.
+func (s *Splitter) dividerAt(i int) int {
+	pos := 0
+	for j := 0; j <= i; j++ {
+		pos += s.sizes[j]
+	}
+	return pos + i
+}
+
+// moveDivider moves divider i to pos, relative to the inner area, as far as
+// the minimum sizes of its neighbours allow.
+func (s *Splitter) moveDivider(i, pos int) {
+	if i < 0 || i >= len(s.panes)-1 {
+		return
+	}
+	a, b := &s.panes[i], &s.panes[i+1]
+	start := s.dividerAt(i) - s.sizes[i]
+	pair := s.sizes[i] + s.sizes[i+1]
+
+	size := pos - start
+	size = MinInt(size, pair-MinInt(b.min, pair))
+	size = MaxInt(size, MinInt(a.min, pair))
+	size = MaxInt(0, MinInt(size, pair))
+	if size == s.sizes[i] {
+		return
+	}
+
+	// The share of a collapsed pane went to its neighbour, so dragging it
+	// open takes it back from the pair.
+	total := a.ratio + b.ratio
+	a.collapsed, b.collapsed = false, false
+	if pair > 0 {
+		a.ratio = total * float64(size) / float64(pair)
+		b.ratio = total - a.ratio
+	}
+	s.normalize()
+	s.ReLayout()
+}
+
+// hitDivider returns the divider at pt, in screen coordinates, or -1.
+func (s *Splitter) hitDivider(pt image.Point) int {
+	inner := s.GetInnerRealPos()
+	if !pt.In(inner) {
+		return -1
+	}
+	pos := s.length(pt.Sub(inner.Min))
+	for i := 0; i < len(s.panes)-1; i++ {
+		if s.dividerAt(i) == pos {
+			return i
+		}
+	}
+	return -1
+}
+
+// toggle collapses the smaller pane next to divider i, or expands it if one
+// of them is collapsed.
+func (s *Splitter) toggle(i int) {
+	switch {
+	case s.IsCollapsed(i):
+		s.Expand(i)
+	case s.IsCollapsed(i + 1):
+		s.Expand(i + 1)
+	case s.sizes[i+1] < s.sizes[i]:
+		s.Collapse(i + 1)
+	default:
+		s.Collapse(i)
+	}
+}
+
+// Draw draws the panes and the dividers between them.
+func (s *Splitter) Draw() {
+	s.Lock()
+	defer s.Unlock()
+
+	p := s.GetPainter()
+	if p == nil {
+		return
+	}
+	s.Block.draw()
+
+	inner := s.GetInnerRealPos()
+	for i := 0; i < len(s.panes)-1; i++ {
+		style := Theme.Splitter.Divider
+		if s.IsFocused() && i == s.active || i == s.drag {
+			style = Theme.Splitter.Active
+		}
+		pos := s.dividerAt(i)
+		if s.layout == Horizontal {
+			x := inner.Min.X + pos
+			p.Fill(Cell{VERTICAL_LINE, style}, image.Rect(x, inner.Min.Y, x+1, inner.Max.Y))
+		} else {
+			y := inner.Min.Y + pos
+			p.Fill(Cell{HORIZONTAL_LINE, style}, image.Rect(inner.Min.X, y, inner.Max.X, y+1))
+		}
+	}
+	for i, w := range s.children {
+		if s.sizes[i] > 0 {
+			w.Draw()
+		}
+	}
+}
+
+// SizeHint returns the size hints of the panes added up along the layout
+// direction, with the dividers.
+func (s *Splitter) SizeHint() image.Point {
+	return s.addDividers(s.WidgetBase.SizeHint())
+}
+
+// MinSizeHint returns the minimum sizes of the panes added up along the
+// layout direction, with the dividers.
+func (s *Splitter) MinSizeHint() image.Point {
+	var size image.Point
+	for _, p := range s.panes {
+		if s.layout == Horizontal {
+			size.X += p.min
+		} else {
+			size.Y += p.min
+		}
+	}
+	return s.addDividers(size).Add(s.FrameSize())
+}
+
+func (s *Splitter) addDividers(size image.Point) image.Point {
+	if n := len(s.panes); n > 1 {
+		if s.layout == Horizontal {
+			size.X += n - 1
+		} else {
+			size.Y += n - 1
+		}
+	}
+	return size
+}
+
+// Keybindings returns the keys that move the dividers.
+func (s *Splitter) Keybindings() []KeyHelp {
+	back, forward := KeyArrowUp, KeyArrowDown
+	if s.layout == Horizontal {
+		back, forward = KeyArrowLeft, KeyArrowRight
+	}
+	return []KeyHelp{
+		{[]string{back}, "Move the divider back"},
+		{[]string{forward}, "Move the divider forward"},
+		{[]string{"[", "]"}, "Select the previous or next divider"},
+		{[]string{KeyEnter}, "Collapse or expand the pane next to the divider"},
+	}
+}
+
+// DoEvent passes key events to the panes first; when none handles it and the
+// Splitter is focused, the keys move the selected divider. Mouse events on
+// a divider drag it, and a double-click collapses or expands the smaller pane
+// next to it.
+func (s *Splitter) DoEvent(e Event) bool {
+	switch e.Type {
+	case KeyboardEvent:
+		if s.WidgetBase.DoEvent(e) {
+			return true
+		}
+		if !s.IsFocused() || len(s.panes) < 2 || !s.doKeyEvent(e) {
+			return false
+		}
+	case MouseEvent:
+		handled, repaint := s.doMouseEvent(e)
+		if !repaint {
+			return handled
+		}
+	case ResizeEvent:
+		return s.WidgetBase.DoEvent(e)
+	default:
+		return false
+	}
+	s.rePaint(s)
+	return true
+}
+
+func (s *Splitter) doKeyEvent(e Event) bool {
+	back, forward := KeyArrowUp, KeyArrowDown
+	if s.layout == Horizontal {
+		back, forward = KeyArrowLeft, KeyArrowRight
+	}
+	switch e.ID {
+	case back:
+		s.moveDivider(s.active, s.dividerAt(s.active)-1)
+	case forward:
+		s.moveDivider(s.active, s.dividerAt(s.active)+1)
+	case "[":
+		s.active = (s.active + len(s.panes) - 2) % (len(s.panes) - 1)
+	case "]":
+		s.active = (s.active + 1) % (len(s.panes) - 1)
+	case KeyEnter:
+		s.toggle(s.active)
+	default:
+		return false
+	}
+	return true
+}
+
+// doMouseEvent reports whether the event was handled and whether the
+// Splitter needs a repaint.
+func (s *Splitter) doMouseEvent(e Event) (bool, bool) {
+	m := e.Payload.(Mouse)
+	pt := image.Pt(m.X, m.Y)
+
+	if s.drag >= 0 {
+		if e.ID == "<MouseRelease>" {
+			s.drag = -1
+		} else {
+			s.moveDivider(s.drag, s.length(pt.Sub(s.GetInnerRealPos().Min)))
+		}
+		return true, true
+	}
+
+	if i := s.hitDivider(pt); i >= 0 {
+		if e.ID != "<MouseLeft>" || m.Drag {
+			return true, false
+		}
+		now := time.Now()
+		if i == s.lastHit && now.Sub(s.lastClick) < doubleClickTime {
+			s.toggle(i)
+			s.lastHit = -1
+		} else {
+			s.drag = i
+			s.lastHit, s.lastClick = i, now
+		}
+		s.active = i
+		return true, true
+	}
+
+	for _, w := range s.children {
+		if pt.In(realOuter(w)) {
+			return w.DoEvent(e), false
+		}
+	}
+	return false, false
+}
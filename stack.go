@@ -0,0 +1,318 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+var _ Widget = &Stack{}
+
+// Anchor determines where a layer of a Stack is placed.
+type Anchor int
+
+// Available anchors.
+const (
+	// AnchorFill makes the layer cover the whole Stack.
+	AnchorFill Anchor = iota
+	AnchorCenter
+	AnchorTopLeft
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+	// AnchorAbsolute places the layer at its offset from the top left
+	// corner of the Stack.
+	AnchorAbsolute
+)
+
+// Layer determines the position and size of a layer of a Stack.
+type Layer struct {
+	Anchor Anchor
+	// Offset moves the layer from its anchored position.
+	Offset image.Point
+	// Size is the size of the layer. Zero uses the size hint of the widget.
+	Size image.Point
+	// Relative anchors the layer to another widget instead of to the Stack.
+	// AnchorBottom* places the layer below the widget, AnchorTop* above it,
+	// AnchorLeft and AnchorRight beside it and AnchorCenter over it.
+	Relative Widget
+	// Modal keeps the layers below from receiving events and the focus, and
+	// the keybindings of the UI from running while the layer is shown.
+	Modal bool
+}
+
+// Stack is a container drawing its widgets on top of each other, the last
+// one topmost. It is used for dialogs, popups, dropdowns and tooltips.
+type Stack struct {
+	Block
+	layers map[Widget]Layer
+}
+
+// NewStack returns a new Stack with the given widgets as layers filling the
+// Stack.
+func NewStack(ws ...Widget) *Stack {
+	s := &Stack{
+		Block:  *NewBlock(),
+		layers: make(map[Widget]Layer),
+	}
+	s.Border = false
+	for _, w := range ws {
+		s.Append(w)
+	}
+	return s
+}
+
+// Push adds w on top of the other layers.
+func (s *Stack) Push(w Widget, l Layer) {
+	s.layers[w] = l
+	s.WidgetBase.Append(w)
+	s.ReLayout()
+}
+
+// Append adds w on top of the other layers, filling the Stack.
+func (s *Stack) Append(w Widget) {
+	s.Push(w, Layer{})
+}
+
+// Pop removes the topmost layer and returns it.
+func (s *Stack) Pop() Widget {
+	n := len(s.children)
+	if n == 0 {
+		return nil
+	}
+	w := s.children[n-1]
+	s.Remove(n - 1)
+	return w
+}
+
+// Remove deletes the layer at index i.
+func (s *Stack) Remove(i int) {
+	if i >= 0 && i < len(s.children) {
+		delete(s.layers, s.children[i])
+	}
+	s.WidgetBase.Remove(i)
+}
+
+// RemoveWidget deletes the layer holding w.
+func (s *Stack) RemoveWidget(w Widget) {
+	for i, c := range s.children {
+		if c == w {
+			s.Remove(i)
+			return
+		}
+	}
+}
+
+// Raise moves the layer holding w to the top.
+func (s *Stack) Raise(w Widget) {
+	for i, c := range s.children {
+		if c == w {
+			copy(s.children[i:], s.children[i+1:])
+			s.children[len(s.children)-1] = w
+			s.Invalidate()
+			s.Refresh()
+			return
+		}
+	}
+}
+
+// SetLayer changes the position and size of the layer holding w.
+func (s *Stack) SetLayer(w Widget, l Layer) {
+	if _, ok := s.layers[w]; ok {
+		s.layers[w] = l
+		s.Invalidate()
+		s.ReLayout()
+	}
+}
+
+// Resize updates the size of the Stack and places its layers.
+func (s *Stack) Resize(pos image.Point, size image.Point) {
+	if !s.needsLayout(pos, size) {
+		return
+	}
+	s.SetRect(pos.X, pos.Y, size.X, size.Y)
+	s.ReLayout()
+}
+
+// ReLayout places the layers.
+func (s *Stack) ReLayout() {
+	for _, w := range s.children {
+		r := s.layerRect(w, s.layers[w])
+		w.Resize(r.Min, r.Size())
+	}
+}
+
+// layerRect returns the rectangle of a layer relative to the inner
+// rectangle of the Stack.
+func (s *Stack) layerRect(w Widget, l Layer) image.Rectangle {
+	bounds := image.Rectangle{Max: s.GetInner().Size()}
+	if l.Anchor == AnchorFill {
+		return bounds
+	}
+
+	size := l.Size
+	if size == (image.Point{}) {
+		size = sizeHintOf(w)
+	}
+	size.X = MinInt(size.X, bounds.Dx())
+	size.Y = MinInt(size.Y, bounds.Dy())
+
+	var pos image.Point
+	if l.Relative != nil {
+		pos = s.relativePos(l.Relative, l.Anchor, size)
+	} else {
+		free := bounds.Size().Sub(size)
+		switch l.Anchor {
+		case AnchorCenter:
+			pos = free.Div(2)
+		case AnchorTop:
+			pos = image.Pt(free.X/2, 0)
+		case AnchorTopRight:
+			pos = image.Pt(free.X, 0)
+		case AnchorLeft:
+			pos = image.Pt(0, free.Y/2)
+		case AnchorRight:
+			pos = image.Pt(free.X, free.Y/2)
+		case AnchorBottomLeft:
+			pos = image.Pt(0, free.Y)
+		case AnchorBottom:
+			pos = image.Pt(free.X/2, free.Y)
+		case AnchorBottomRight:
+			pos = free
+		}
+	}
+	pos = pos.Add(l.Offset)
+
+	// Keep the layer inside the Stack.
+	pos.X = MaxInt(0, MinInt(pos.X, bounds.Dx()-size.X))
+	pos.Y = MaxInt(0, MinInt(pos.Y, bounds.Dy()-size.Y))
+	return image.Rectangle{pos, pos.Add(size)}
+}
+
+// relativePos returns the position of a layer of the given size anchored to
+// the widget rel.
+func (s *Stack) relativePos(rel Widget, a Anchor, size image.Point) image.Point {
+	r := realOuter(rel).Sub(s.GetInnerRealPos().Min)
+	center := image.Pt(r.Min.X+(r.Dx()-size.X)/2, r.Min.Y+(r.Dy()-size.Y)/2)
+	switch a {
+	case AnchorTopLeft:
+		return image.Pt(r.Min.X, r.Min.Y-size.Y)
+	case AnchorTop:
+		return image.Pt(center.X, r.Min.Y-size.Y)
+	case AnchorTopRight:
+		return image.Pt(r.Max.X-size.X, r.Min.Y-size.Y)
+	case AnchorLeft:
+		return image.Pt(r.Min.X-size.X, center.Y)
+	case AnchorRight:
+		return image.Pt(r.Max.X, center.Y)
+	case AnchorBottomLeft:
+		return image.Pt(r.Min.X, r.Max.Y)
+	case AnchorBottom:
+		return image.Pt(center.X, r.Max.Y)
+	case AnchorBottomRight:
+		return image.Pt(r.Max.X-size.X, r.Max.Y)
+	case AnchorAbsolute:
+		return r.Min
+	}
+	return center
+}
+
+// SizeHint returns the size needed by the largest layer.
+func (s *Stack) SizeHint() image.Point {
+	var size image.Point
+	for _, w := range s.children {
+		hint := sizeHintOf(w)
+		size.X = MaxInt(size.X, hint.X)
+		size.Y = MaxInt(size.Y, hint.Y)
+	}
+	return size.Add(s.FrameSize())
+}
+
+// MinSizeHint returns the minimum size of the largest filling layer.
+func (s *Stack) MinSizeHint() image.Point {
+	var size image.Point
+	for _, w := range s.children {
+		if s.layers[w].Anchor != AnchorFill {
+			continue
+		}
+		hint := minSizeHintOf(w)
+		size.X = MaxInt(size.X, hint.X)
+		size.Y = MaxInt(size.Y, hint.Y)
+	}
+	return size.Add(s.FrameSize())
+}
+
+// modalIndex returns the index of the topmost modal layer, or -1 if there is
+// none.
+func (s *Stack) modalIndex() int {
+	for i := len(s.children) - 1; i >= 0; i-- {
+		if s.layers[s.children[i]].Modal {
+			return i
+		}
+	}
+	return -1
+}
+
+// modalShown reports whether a Stack within root shows a modal layer.
+func modalShown(root Widget) bool {
+	var shown bool
+	walkWidgets(root, func(w Widget) bool {
+		if s, ok := w.(*Stack); ok && s.modalIndex() >= 0 {
+			shown = true
+		}
+		return !shown
+	})
+	return shown
+}
+
+// belowModal reports whether w is within root and covered by a modal layer
+// of a Stack, so that it must not get the focus.
+func belowModal(root, w Widget) (found, covered bool) {
+	if root == w {
+		return true, false
+	}
+	modal := -1
+	if s, ok := root.(*Stack); ok {
+		modal = s.modalIndex()
+	}
+	for i, child := range root.Children() {
+		if found, covered := belowModal(child, w); found {
+			return true, covered || i < modal
+		}
+	}
+	return false, false
+}
+
+// DoEvent passes mouse events to the topmost layer under the pointer and key
+// events to the layers from the top down until one handles it. A modal layer
+// keeps the events from the layers below.
+func (s *Stack) DoEvent(e Event) bool {
+	switch e.Type {
+	case MouseEvent:
+		m := e.Payload.(Mouse)
+		pt := image.Pt(m.X, m.Y)
+		for i := len(s.children) - 1; i >= 0; i-- {
+			w := s.children[i]
+			if pt.In(realOuter(w)) {
+				return w.DoEvent(e)
+			}
+			if s.layers[w].Modal {
+				return true
+			}
+		}
+	case KeyboardEvent:
+		for i := len(s.children) - 1; i >= 0; i-- {
+			w := s.children[i]
+			if w.DoEvent(e) || s.layers[w].Modal {
+				return true
+			}
+		}
+	case ResizeEvent:
+		return s.WidgetBase.DoEvent(e)
+	}
+	return false
+}
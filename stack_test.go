@@ -0,0 +1,80 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"image"
+	"testing"
+)
+
+func TestStackRaiseRepaints(t *testing.T) {
+	a, b := NewLabel("a"), NewLabel("b")
+	s := NewStack(a, b)
+	p := NewPainter()
+	s.SetPainter(p)
+
+	s.Raise(a)
+	if got := s.Children(); got[0] != b || got[1] != a {
+		t.Fatalf("Raise(a) left the layers as %v", got)
+	}
+	select {
+	case <-p.drawQueue:
+	default:
+		t.Error("Raise didn't request a repaint")
+	}
+}
+
+func TestTabSkipsLayersBelowModal(t *testing.T) {
+	below1, below2 := NewButton("below 1"), NewButton("below 2")
+	dialog1, dialog2 := NewButton("ok"), NewButton("cancel")
+	dialog := NewVBox(dialog1, dialog2)
+	s := NewStack(NewVBox(below1, below2))
+	s.Push(dialog, Layer{Anchor: AnchorCenter, Modal: true})
+
+	ui, err := newTcellUI(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &SimpleFocusChain{}
+	chain.Set(below1, dialog1, below2, dialog2)
+	ui.SetFocusChain(chain)
+	ui.kbFocus.focusedWidget = below1
+
+	want := []Widget{dialog1, dialog2, dialog1}
+	for i, w := range want {
+		if !ui.kbFocus.OnKeyEvent(Event{Type: KeyboardEvent, ID: KeyTab}) {
+			t.Fatalf("Tab %d wasn't handled", i+1)
+		}
+		if got := ui.kbFocus.focusedWidget; got != w {
+			t.Fatalf("Tab %d focused %v, want %v", i+1, got, w)
+		}
+	}
+
+	s.SetLayer(dialog, Layer{Anchor: AnchorCenter})
+	ui.kbFocus.focusedWidget = below1
+	ui.kbFocus.OnKeyEvent(Event{Type: KeyboardEvent, ID: KeyTab})
+	if got := ui.kbFocus.focusedWidget; got != dialog1 {
+		t.Errorf("without the modal layer Tab focused %v, want %v", got, dialog1)
+	}
+}
+
+func TestModalStopsKeybindings(t *testing.T) {
+	s := NewStack(NewLabel("below"))
+	ui, err := newTcellUI(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.size = image.Pt(20, 5)
+	var calls int
+	ui.SetKeybinding("x", func() { calls++ })
+
+	ui.handleEvent(Event{Type: KeyboardEvent, ID: "x"})
+	s.Push(NewLabel("dialog"), Layer{Anchor: AnchorCenter, Modal: true})
+	ui.handleEvent(Event{Type: KeyboardEvent, ID: "x"})
+
+	if calls != 1 {
+		t.Errorf("keybinding ran %d times, want once before the modal layer was shown", calls)
+	}
+}
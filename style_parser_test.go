@@ -0,0 +1,72 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"reflect"
+	"testing"
+)
+
+// styledRun is text drawn in a single style.
+type styledRun struct {
+	Text  string
+	Style Style
+}
+
+// styledRuns merges cells of the same style into runs.
+func styledRuns(cells []Cell) []styledRun {
+	runs := []styledRun{}
+	for _, c := range cells {
+		if n := len(runs); n > 0 && runs[n-1].Style == c.Style {
+			runs[n-1].Text += string(c.Rune)
+			continue
+		}
+		runs = append(runs, styledRun{string(c.Rune), c.Style})
+	}
+	return runs
+}
+
+func TestParseStyles(t *testing.T) {
+	def := NewStyle(ColorWhite)
+	red := NewStyle(ColorRed)
+	tests := []struct {
+		name string
+		in   string
+		want []styledRun
+	}{
+		{"plain", "hello", []styledRun{{"hello", def}}},
+		{"empty", "", []styledRun{}},
+		{"styled", "[hi](fg:red)", []styledRun{{"hi", red}}},
+		{
+			"around text",
+			"a [b](fg:blue,bg:yellow,mod:bold) c",
+			[]styledRun{
+				{"a ", def},
+				{"b", NewStyle(ColorBlue, ColorYellow, ModifierBold)},
+				{" c", def},
+			},
+		},
+		{"any order", "[b](mod:underline,fg:green)", []styledRun{{"b", NewStyle(ColorGreen, ColorClear, ModifierUnderline)}}},
+		{"unknown items", "[b](fg:red,size:2,oops)", []styledRun{{"b", red}}},
+		{"nested brackets", "[[x]](fg:red)", []styledRun{{"[x]", red}}},
+		{"two styles", "[a](fg:red)[b](fg:red)", []styledRun{{"ab", red}}},
+		{"brackets without style", "[a] b", []styledRun{{"[a] b", def}}},
+		{"unterminated text", "x [abc", []styledRun{{"x [abc", def}}},
+		{"unterminated style", "[abc](fg:red", []styledRun{{"[abc](fg:red", def}}},
+		{"style without items", "[abc](", []styledRun{{"[abc](", def}}},
+		{"bracket at the end", "[a][", []styledRun{{"[a][", def}}},
+		{"closing bracket at the end", "[abc]", []styledRun{{"[abc]", def}}},
+		{"bracket after brackets", "[a][b](fg:red)", []styledRun{{"[a]", def}, {"b", red}}},
+		{"wide characters", "[世界](fg:red)!", []styledRun{{"世界", red}, {"!", def}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := styledRuns(ParseStyles(tt.in, def))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseStyles(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
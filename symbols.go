@@ -15,6 +15,28 @@ const (
 	EXPANDED  = '−'
 )
 
+// Glyphs of checkboxes, toggles, radio buttons and selects, with ASCII
+// fallbacks.
+const (
+	CHECKBOX_UNCHECKED     = "☐"
+	CHECKBOX_CHECKED       = "☑"
+	CHECKBOX_INDETERMINATE = "⊟"
+	TOGGLE_ON              = "━━●"
+	TOGGLE_OFF             = "●━━"
+	RADIO_SELECTED         = "◉"
+	RADIO_UNSELECTED       = "○"
+	SELECT_ARROW           = "▼"
+
+	ASCII_CHECKBOX_UNCHECKED     = "[ ]"
+	ASCII_CHECKBOX_CHECKED       = "[x]"
+	ASCII_CHECKBOX_INDETERMINATE = "[-]"
+	ASCII_TOGGLE_ON              = "[ on]"
+	ASCII_TOGGLE_OFF             = "[off]"
+	ASCII_RADIO_SELECTED         = "(*)"
+	ASCII_RADIO_UNSELECTED       = "( )"
+	ASCII_SELECT_ARROW           = "v"
+)
+
 var (
 	BARS = [...]rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 
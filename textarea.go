@@ -0,0 +1,461 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"fmt"
+	"image"
+	"unicode"
+)
+
+var _ Widget = &TextArea{}
+
+// textRow is a row of text as shown on screen: a whole line, or a part of one
+// when it is wrapped. start and end are rune indices in the buffer; end
+// excludes the line break.
+type textRow struct {
+	start, end int
+	// last is set on the last row of a line.
+	last bool
+}
+
+// TextArea is a multi-line text editor. Long lines are wrapped at word
+// boundaries, and the view scrolls to follow the cursor.
+type TextArea struct {
+	Block
+	text RuneBuffer
+
+	wrap        bool
+	lineNumbers bool
+	maxLength   int
+
+	// goalX is the column the cursor tries to stay in when moving up and
+	// down, or -1 if it should use its current column.
+	goalX int
+	// top is the first row shown; left is the first column shown when lines
+	// aren't wrapped.
+	top, left int
+
+	onTextChange func(*TextArea)
+}
+
+// NewTextArea returns a new, empty TextArea with wrapping enabled.
+func NewTextArea() *TextArea {
+	t := &TextArea{
+		Block: *NewBlock(),
+		wrap:  true,
+		goalX: -1,
+	}
+	t.style = Theme.TextArea.Text
+	return t
+}
+
+// Text returns the text content of the TextArea.
+func (t *TextArea) Text() string {
+	return t.text.String()
+}
+
+// SetText sets the text content of the TextArea and moves the cursor to its
+// end. Text beyond the maximum length is dropped.
+func (t *TextArea) SetText(text string) {
+	t.Lock()
+	defer t.Unlock()
+	runes := []rune(text)
+	if t.maxLength > 0 && len(runes) > t.maxLength {
+		runes = runes[:t.maxLength]
+	}
+	t.text.Set(runes)
+	t.goalX = -1
+	t.rePaint(t)
+}
+
+// SetWordWrap sets whether long lines are wrapped. Unwrapped lines scroll
+// horizontally.
+func (t *TextArea) SetWordWrap(enabled bool) {
+	t.wrap = enabled
+	t.left = 0
+}
+
+// SetLineNumbers sets whether line numbers are shown left of the text.
+func (t *TextArea) SetLineNumbers(enabled bool) {
+	t.lineNumbers = enabled
+}
+
+// SetMaxLength sets the maximum number of characters, line breaks included.
+// Zero means no limit.
+func (t *TextArea) SetMaxLength(n int) {
+	t.maxLength = n
+}
+
+// OnChanged sets a function to be run whenever the text has been changed.
+func (t *TextArea) OnChanged(fn func(*TextArea)) {
+	t.onTextChange = fn
+}
+
+// CursorPos returns the line and column of the cursor in the text, counted
+// in characters from zero.
+func (t *TextArea) CursorPos() image.Point {
+	var pt image.Point
+	for _, r := range t.text.Runes()[:t.text.Pos()] {
+		if r == '\n' {
+			pt.Y++
+			pt.X = 0
+		} else {
+			pt.X++
+		}
+	}
+	return pt
+}
+
+// gutterWidth returns the width of the line numbers, including the space
+// after them.
+func (t *TextArea) gutterWidth() int {
+	if !t.lineNumbers {
+		return 0
+	}
+	lines := 1
+	for _, r := range t.text.Runes() {
+		if r == '\n' {
+			lines++
+		}
+	}
+	return len(fmt.Sprint(lines)) + 1
+}
+
+// textWidth returns the width available to the text.
+func (t *TextArea) textWidth() int {
+	return t.GetInner().Dx() - t.gutterWidth()
+}
+
+// rows splits the text into the rows shown on screen for the given width.
+func (t *TextArea) rows(width int) []textRow {
+	buf := t.text.Runes()
+	var rows []textRow
+	start := 0
+	for i := 0; i <= len(buf); i++ {
+		if i < len(buf) && buf[i] != '\n' {
+			continue
+		}
+		if t.wrap && width > 0 {
+			rows = append(rows, wrapRow(buf, start, i, width)...)
+		} else {
+			rows = append(rows, textRow{start, i, true})
+		}
+		start = i + 1
+	}
+	return rows
+}
+
+// wrapRow splits the line buf[start:end] into rows of at most width cells,
+// breaking after the last space that fits when there is one.
+func wrapRow(buf []rune, start, end, width int) []textRow {
+	var rows []textRow
+	rowStart, w, lastSpace := start, 0, -1
+	for i := start; i < end; i++ {
+		rw := runeWidth(buf[i])
+		if w+rw > width && i > rowStart {
+			brk := i
+			if lastSpace > rowStart {
+				brk = lastSpace
+			}
+			rows = append(rows, textRow{rowStart, brk, false})
+			rowStart, lastSpace = brk, -1
+			w = 0
+			for j := brk; j < i; j++ {
+				w += runeWidth(buf[j])
+			}
+		}
+		w += rw
+		if unicode.IsSpace(buf[i]) {
+			lastSpace = i + 1
+		}
+	}
+	return append(rows, textRow{rowStart, end, true})
+}
+
+// cursorRow returns the index of the row holding the cursor. At the boundary
+// of a wrapped line, the cursor is at the start of the next row.
+func cursorRow(rows []textRow, idx int) int {
+	for i, r := range rows {
+		if idx >= r.start && (idx < r.end || idx == r.end && r.last) {
+			return i
+		}
+	}
+	return len(rows) - 1
+}
+
+// columnOf returns the column, in cells, of idx in row r.
+func (t *TextArea) columnOf(r textRow, idx int) int {
+	return stringWidth(string(t.text.Runes()[r.start:idx]))
+}
+
+// indexAt returns the index in row r closest to column x, in cells.
+func (t *TextArea) indexAt(r textRow, x int) int {
+	buf := t.text.Runes()
+	end := r.end
+	if !r.last && end > r.start {
+		// The end of a wrapped row is the start of the next one.
+		end--
+	}
+	var w int
+	for i := r.start; i < end; i++ {
+		rw := runeWidth(buf[i])
+		if w+rw > x {
+			return i
+		}
+		w += rw
+	}
+	return end
+}
+
+// scrollToCursor scrolls as little as needed to show the cursor.
+func (t *TextArea) scrollToCursor() {
+	inner := t.GetInner()
+	rows := t.rows(t.textWidth())
+	row := cursorRow(rows, t.text.Pos())
+	if row < t.top {
+		t.top = row
+	}
+	if h := inner.Dy(); h > 0 && row >= t.top+h {
+		t.top = row - h + 1
+	}
+	t.top = MaxInt(0, MinInt(t.top, len(rows)-1))
+
+	if t.wrap {
+		t.left = 0
+		return
+	}
+	x := t.columnOf(rows[row], t.text.Pos())
+	if w := t.textWidth(); w > 0 {
+		if x < t.left {
+			t.left = x
+		}
+		if x >= t.left+w {
+			t.left = x - w + 1
+		}
+	}
+}
+
+// SizeHint returns the recommended size for the TextArea.
+func (t *TextArea) SizeHint() image.Point {
+	return image.Point{40, 5}.Add(t.FrameSize())
+}
+
+// MinSizeHint returns the minimum size for the TextArea.
+func (t *TextArea) MinSizeHint() image.Point {
+	return image.Point{10, 3}.Add(t.FrameSize())
+}
+
+// Draw draws the visible rows of text, the line numbers and the cursor.
+func (t *TextArea) Draw() {
+	t.Lock()
+	defer t.Unlock()
+
+	p := t.GetPainter()
+	if p == nil {
+		return
+	}
+	t.Block.draw()
+	t.scrollToCursor()
+
+	inner := t.GetInnerRealPos()
+	gutter := t.gutterWidth()
+	buf := t.text.Runes()
+	rows := t.rows(t.textWidth())
+	line := 1
+	for i := 0; i < t.top; i++ {
+		if rows[i].last {
+			line++
+		}
+	}
+
+	p.PushClip(inner)
+	for i := t.top; i < len(rows) && i-t.top < inner.Dy(); i++ {
+		r := rows[i]
+		y := inner.Min.Y + i - t.top
+		if gutter > 0 && (i == 0 || rows[i-1].last) {
+			num := fmt.Sprintf("%*d", gutter-1, line)
+			p.SetString(num, Theme.TextArea.LineNumber, image.Pt(inner.Min.X, y))
+		}
+		x := inner.Min.X + gutter - t.left
+		for _, c := range buf[r.start:r.end] {
+			if x >= inner.Min.X+gutter {
+				p.SetCell(Cell{c, t.style}, image.Pt(x, y))
+			}
+			x += runeWidth(c)
+		}
+		if r.last {
+			line++
+		}
+	}
+	p.PopClip()
+
+	if t.IsFocused() && len(rows) > 0 {
+		row := cursorRow(rows, t.text.Pos())
+		x := t.columnOf(rows[row], t.text.Pos()) - t.left
+		p.DrawCursor(inner.Min.X+gutter+x, inner.Min.Y+row-t.top)
+	}
+}
+
+// Keybindings returns the keys handled by the TextArea.
+func (t *TextArea) Keybindings() []KeyHelp {
+	return []KeyHelp{
+		{[]string{KeyEnter}, "Insert a line break"},
+		{[]string{KeyBackspace2}, "Delete the character before the cursor"},
+		{[]string{KeyDelete, KeyCtrlD}, "Delete the character under the cursor"},
+		{[]string{KeyArrowLeft, KeyCtrlB}, "Move back one character"},
+		{[]string{KeyArrowRight, KeyCtrlF}, "Move forward one character"},
+		{[]string{KeyArrowUp, KeyCtrlP}, "Move up one row"},
+		{[]string{KeyArrowDown, KeyCtrlN}, "Move down one row"},
+		{[]string{KeyPgup, KeyPgdn}, "Move up or down one page"},
+		{[]string{KeyHome, KeyCtrlA}, "Move to the start of the line"},
+		{[]string{KeyEnd, KeyCtrlE}, "Move to the end of the line"},
+		{[]string{KeyCtrlK}, "Delete to the end of the line"},
+	}
+}
+
+// DoEvent edits the text when the TextArea is focused. Clicking places the
+// cursor and the mouse wheel scrolls.
+func (t *TextArea) DoEvent(e Event) bool {
+	switch e.Type {
+	case KeyboardEvent:
+		if !t.IsFocused() || !t.doKeyEvent(e) {
+			return false
+		}
+	case MouseEvent:
+		if !t.doMouseEvent(e) {
+			return false
+		}
+	default:
+		return false
+	}
+	t.rePaint(t)
+	return true
+}
+
+func (t *TextArea) doKeyEvent(e Event) bool {
+	if isCharKey(e.ID) {
+		t.insert([]rune(e.ID))
+		return true
+	}
+	vertical := false
+	switch e.ID {
+	case KeySpace:
+		t.insert([]rune{' '})
+	case KeyEnter:
+		t.insert([]rune{'\n'})
+	case KeyBackspace, KeyBackspace2:
+		t.edit(t.text.Backspace)
+	case KeyDelete, KeyCtrlD:
+		t.edit(t.text.Delete)
+	case KeyCtrlK:
+		t.edit(t.text.Kill)
+	case KeyArrowLeft, KeyCtrlB:
+		t.text.MoveBackward()
+	case KeyArrowRight, KeyCtrlF:
+		t.text.MoveForward()
+	case KeyHome, KeyCtrlA:
+		t.text.MoveToLineStart()
+	case KeyEnd, KeyCtrlE:
+		t.text.MoveToLineEnd()
+	case KeyArrowUp, KeyCtrlP:
+		t.moveRows(-1)
+		vertical = true
+	case KeyArrowDown, KeyCtrlN:
+		t.moveRows(1)
+		vertical = true
+	case KeyPgup:
+		t.moveRows(-MaxInt(t.GetInner().Dy(), 1))
+		vertical = true
+	case KeyPgdn:
+		t.moveRows(MaxInt(t.GetInner().Dy(), 1))
+		vertical = true
+	default:
+		return false
+	}
+	if !vertical {
+		t.goalX = -1
+	}
+	return true
+}
+
+// moveRows moves the cursor n rows down, or up if n is negative, keeping it
+// in the preferred column.
+func (t *TextArea) moveRows(n int) {
+	rows := t.rows(t.textWidth())
+	row := cursorRow(rows, t.text.Pos())
+	if t.goalX < 0 {
+		t.goalX = t.columnOf(rows[row], t.text.Pos())
+	}
+	target := MaxInt(0, MinInt(row+n, len(rows)-1))
+	if target == row {
+		// Moving past the first or last row goes to its start or end.
+		if n < 0 {
+			t.text.MoveTo(rows[row].start)
+		} else {
+			t.text.MoveTo(rows[row].end)
+		}
+		t.goalX = -1
+		return
+	}
+	t.text.MoveTo(t.indexAt(rows[target], t.goalX))
+}
+
+// insert writes runes at the cursor, dropping those beyond the maximum
+// length.
+func (t *TextArea) insert(runes []rune) {
+	if t.maxLength > 0 {
+		room := t.maxLength - t.text.Len()
+		if room <= 0 {
+			return
+		}
+		if len(runes) > room {
+			runes = runes[:room]
+		}
+	}
+	t.edit(func() { t.text.WriteRunes(runes) })
+}
+
+// edit applies fn to the text and notifies the change.
+func (t *TextArea) edit(fn func()) {
+	before := t.text.Len()
+	pos := t.text.Pos()
+	fn()
+	t.goalX = -1
+	if t.text.Len() != before || t.text.Pos() != pos {
+		if t.onTextChange != nil {
+			t.onTextChange(t)
+		}
+	}
+}
+
+func (t *TextArea) doMouseEvent(e Event) bool {
+	if n := 0; e.ID == "<MouseWheelUp>" || e.ID == "<MouseWheelDown>" {
+		m := e.Payload.(Mouse)
+		if !image.Pt(m.X, m.Y).In(realOuter(t)) {
+			return false
+		}
+		n = scrollWheelStep
+		if e.ID == "<MouseWheelUp>" {
+			n = -n
+		}
+		t.moveRows(n)
+		return true
+	}
+	pt, ok := clickedAt(t, e)
+	if !ok {
+		return false
+	}
+	inner := t.GetInnerRealPos()
+	rows := t.rows(t.textWidth())
+	row := t.top + pt.Y - inner.Min.Y
+	if row < 0 || row >= len(rows) || !pt.In(inner) {
+		return true
+	}
+	x := pt.X - inner.Min.X - t.gutterWidth() + t.left
+	t.text.MoveTo(t.indexAt(rows[row], MaxInt(x, 0)))
+	t.goalX = -1
+	return true
+}
@@ -42,6 +42,18 @@ type RootTheme struct {
 	StackedBarChart StackedBarChartTheme
 	Tab             TabTheme
 	Table           TableTheme
+	Palette         PaletteTheme
+	Help            HelpTheme
+	ScrollBar       ScrollBarTheme
+	Splitter        SplitterTheme
+	Inspector       InspectorTheme
+	Button          ButtonTheme
+	Checkbox        CheckboxTheme
+	Toggle          ToggleTheme
+	Radio           RadioTheme
+	Input           InputTheme
+	TextArea        TextAreaTheme
+	Select          SelectTheme
 }
 
 type BlockTheme struct {
@@ -66,7 +78,11 @@ type PlotTheme struct {
 }
 
 type ListTheme struct {
-	Text Style
+	Text     Style
+	Selected Style
+	Marked   Style
+	Match    Style
+	Filter   Style
 }
 
 type TreeTheme struct {
@@ -103,6 +119,95 @@ type TableTheme struct {
 	Text Style
 }
 
+type PaletteTheme struct {
+	Border      Style
+	Text        Style
+	Selected    Style
+	Match       Style
+	Key         Style
+	Description Style
+}
+
+type ScrollBarTheme struct {
+	Track rune
+	Thumb rune
+	Style Style
+}
+
+type SplitterTheme struct {
+	Divider Style
+	Active  Style
+}
+
+type InspectorTheme struct {
+	Outer    Style
+	Margin   Style
+	Padding  Style
+	Inner    Style
+	Title    Style
+	Text     Style
+	Selected Style
+}
+
+type ButtonTheme struct {
+	Normal   Style
+	Focused  Style
+	Hovered  Style
+	Pressed  Style
+	Disabled Style
+}
+
+type CheckboxTheme struct {
+	Unchecked     string
+	Checked       string
+	Indeterminate string
+	Text          Style
+	Focused       Style
+}
+
+type ToggleTheme struct {
+	On      string
+	Off     string
+	Text    Style
+	Focused Style
+	Active  Style
+}
+
+type RadioTheme struct {
+	Selected   string
+	Unselected string
+	Text       Style
+	Focused    Style
+}
+
+type InputTheme struct {
+	Text               Style
+	Selection          Style
+	Invalid            Style
+	Error              Style
+	Search             Style
+	Suggestion         Style
+	SuggestionSelected Style
+}
+
+type TextAreaTheme struct {
+	Text       Style
+	LineNumber Style
+}
+
+type SelectTheme struct {
+	Arrow    string
+	Item     Style
+	Selected Style
+}
+
+type HelpTheme struct {
+	Border Style
+	Scope  Style
+	Key    Style
+	Text   Style
+}
+
 // DefaultTheme is a theme with reasonable defaults.
 var DefaultTheme = &RootTheme{
 	styles: map[string]Style{
@@ -137,7 +242,11 @@ var Theme = RootTheme{
 	},
 
 	List: ListTheme{
-		Text: NewStyle(ColorWhite),
+		Text:     NewStyle(ColorWhite),
+		Selected: NewStyle(ColorBlack, ColorWhite),
+		Marked:   NewStyle(ColorBlack, ColorCyan),
+		Match:    NewStyle(ColorYellow, ColorClear, ModifierUnderline),
+		Filter:   NewStyle(ColorYellow),
 	},
 
 	Tree: TreeTheme{
@@ -175,6 +284,109 @@ var Theme = RootTheme{
 		Active:   NewStyle(ColorRed),
 		Inactive: NewStyle(ColorWhite),
 	},
+
+	Palette: PaletteTheme{
+		Border:      NewStyle(ColorCyan),
+		Text:        NewStyle(ColorWhite),
+		Selected:    NewStyle(ColorWhite, ColorClear, ModifierReverse),
+		Match:       NewStyle(ColorYellow),
+		Key:         NewStyle(ColorCyan),
+		Description: NewStyle(ColorWhite),
+	},
+
+	Help: HelpTheme{
+		Border: NewStyle(ColorCyan),
+		Scope:  NewStyle(ColorYellow, ColorClear, ModifierBold),
+		Key:    NewStyle(ColorCyan),
+		Text:   NewStyle(ColorWhite),
+	},
+
+	ScrollBar: ScrollBarTheme{
+		Track: SHADED_BLOCKS[1],
+		Thumb: SHADED_BLOCKS[4],
+		Style: NewStyle(ColorWhite),
+	},
+
+	Splitter: SplitterTheme{
+		Divider: NewStyle(ColorWhite),
+		Active:  NewStyle(ColorCyan, ColorClear, ModifierBold),
+	},
+
+	Inspector: InspectorTheme{
+		Outer:    NewStyle(ColorRed),
+		Margin:   NewStyle(ColorYellow),
+		Padding:  NewStyle(ColorGreen),
+		Inner:    NewStyle(ColorBlue),
+		Title:    NewStyle(ColorYellow, ColorBlack, ModifierBold),
+		Text:     NewStyle(ColorWhite, ColorBlack),
+		Selected: NewStyle(ColorBlack, ColorCyan),
+	},
+
+	Button: ButtonTheme{
+		Normal:   NewStyle(ColorWhite),
+		Focused:  NewStyle(ColorBlack, ColorWhite),
+		Hovered:  NewStyle(ColorCyan),
+		Pressed:  NewStyle(ColorBlack, ColorCyan),
+		Disabled: NewStyle(ColorBlack, ColorClear, ModifierBold),
+	},
+
+	Checkbox: CheckboxTheme{
+		Unchecked:     CHECKBOX_UNCHECKED,
+		Checked:       CHECKBOX_CHECKED,
+		Indeterminate: CHECKBOX_INDETERMINATE,
+		Text:          NewStyle(ColorWhite),
+		Focused:       NewStyle(ColorBlack, ColorWhite),
+	},
+
+	Toggle: ToggleTheme{
+		On:      TOGGLE_ON,
+		Off:     TOGGLE_OFF,
+		Text:    NewStyle(ColorWhite),
+		Focused: NewStyle(ColorBlack, ColorWhite),
+		Active:  NewStyle(ColorGreen),
+	},
+
+	Radio: RadioTheme{
+		Selected:   RADIO_SELECTED,
+		Unselected: RADIO_UNSELECTED,
+		Text:       NewStyle(ColorWhite),
+		Focused:    NewStyle(ColorBlack, ColorWhite),
+	},
+
+	Input: InputTheme{
+		Text:               NewStyle(ColorWhite),
+		Selection:          NewStyle(ColorWhite, ColorClear, ModifierReverse),
+		Invalid:            NewStyle(ColorRed),
+		Error:              NewStyle(ColorRed),
+		Search:             NewStyle(ColorYellow),
+		Suggestion:         NewStyle(ColorWhite, ColorBlack),
+		SuggestionSelected: NewStyle(ColorBlack, ColorCyan),
+	},
+
+	TextArea: TextAreaTheme{
+		Text:       NewStyle(ColorWhite),
+		LineNumber: NewStyle(ColorYellow),
+	},
+
+	Select: SelectTheme{
+		Arrow:    SELECT_ARROW,
+		Item:     NewStyle(ColorWhite, ColorBlack),
+		Selected: NewStyle(ColorBlack, ColorCyan),
+	},
+}
+
+// UseASCIIGlyphs draws checkboxes, toggles, radio buttons and selects with
+// plain ASCII, for terminals or fonts lacking the Unicode glyphs. Like the
+// rest of the Theme, it is meant to be called before the widgets are created.
+func UseASCIIGlyphs() {
+	Theme.Checkbox.Unchecked = ASCII_CHECKBOX_UNCHECKED
+	Theme.Checkbox.Checked = ASCII_CHECKBOX_CHECKED
+	Theme.Checkbox.Indeterminate = ASCII_CHECKBOX_INDETERMINATE
+	Theme.Toggle.On = ASCII_TOGGLE_ON
+	Theme.Toggle.Off = ASCII_TOGGLE_OFF
+	Theme.Radio.Selected = ASCII_RADIO_SELECTED
+	Theme.Radio.Unselected = ASCII_RADIO_UNSELECTED
+	Theme.Select.Arrow = ASCII_SELECT_ARROW
 }
 
 // NewTheme return an empty theme.
@@ -0,0 +1,149 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import "image"
+
+var _ Widget = &Toggle{}
+
+// Toggle is an on/off switch followed by a text. It is switched with Enter or
+// Space when focused, or by a click; Left switches it off and Right on.
+type Toggle struct {
+	Block
+	text string
+	on   bool
+
+	onChanged func(*Toggle)
+}
+
+// NewToggle returns a new Toggle that is off.
+func NewToggle(text string) *Toggle {
+	t := &Toggle{
+		Block: *NewBlock(),
+		text:  text,
+	}
+	t.Border = false
+	t.sizePolicyY = Minimum
+	return t
+}
+
+// Text returns the text of the toggle.
+func (t *Toggle) Text() string {
+	return t.text
+}
+
+// SetText sets the text of the toggle.
+func (t *Toggle) SetText(text string) {
+	t.text = text
+	t.Invalidate()
+	t.rePaint(t)
+}
+
+// IsOn returns whether the toggle is on.
+func (t *Toggle) IsOn() bool {
+	return t.on
+}
+
+// SetOn switches the toggle on or off.
+func (t *Toggle) SetOn(on bool) {
+	if on == t.on {
+		return
+	}
+	t.on = on
+	if t.onChanged != nil {
+		t.onChanged(t)
+	}
+	t.rePaint(t)
+}
+
+// Toggle switches the toggle.
+func (t *Toggle) Toggle() {
+	t.SetOn(!t.on)
+}
+
+// OnChanged sets the function called when the toggle is switched.
+func (t *Toggle) OnChanged(fn func(*Toggle)) {
+	t.onChanged = fn
+}
+
+func (t *Toggle) glyph() string {
+	if t.on {
+		return Theme.Toggle.On
+	}
+	return Theme.Toggle.Off
+}
+
+// SizeHint returns the size of the switch and the text.
+func (t *Toggle) SizeHint() image.Point {
+	return image.Pt(stringWidth(t.glyph())+1+stringWidth(t.text), 1).Add(t.FrameSize())
+}
+
+// MinSizeHint returns the size of the switch.
+func (t *Toggle) MinSizeHint() image.Point {
+	return image.Pt(stringWidth(t.glyph()), 1).Add(t.FrameSize())
+}
+
+// Draw draws the switch and the text, highlighted when focused.
+func (t *Toggle) Draw() {
+	t.Lock()
+	defer t.Unlock()
+
+	p := t.GetPainter()
+	if p == nil {
+		return
+	}
+	t.Block.draw()
+	text := Theme.Toggle.Text
+	if t.IsFocused() {
+		text = Theme.Toggle.Focused
+	}
+	glyph := text
+	if t.on {
+		glyph = Theme.Toggle.Active
+	}
+	inner := t.GetInnerRealPos()
+	p.PushClip(inner)
+	p.SetString(t.glyph(), glyph, inner.Min)
+	p.SetString(" "+t.text, text, inner.Min.Add(image.Pt(stringWidth(t.glyph()), 0)))
+	p.PopClip()
+}
+
+// Keybindings returns the keys handled by the toggle.
+func (t *Toggle) Keybindings() []KeyHelp {
+	return []KeyHelp{
+		{[]string{KeyEnter, KeySpace}, "Switch on or off"},
+		{[]string{KeyArrowLeft}, "Switch off"},
+		{[]string{KeyArrowRight}, "Switch on"},
+	}
+}
+
+// DoEvent switches the toggle with the keys when it's focused, or when it's
+// clicked.
+func (t *Toggle) DoEvent(e Event) bool {
+	switch e.Type {
+	case KeyboardEvent:
+		if !t.IsFocused() {
+			return false
+		}
+		switch e.ID {
+		case KeyEnter, KeySpace:
+			t.Toggle()
+		case KeyArrowLeft:
+			t.SetOn(false)
+		case KeyArrowRight:
+			t.SetOn(true)
+		default:
+			return false
+		}
+	case MouseEvent:
+		if _, ok := clickedAt(t, e); !ok {
+			return false
+		}
+		t.Toggle()
+	default:
+		return false
+	}
+	return true
+}
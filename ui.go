@@ -22,8 +22,46 @@ type UI interface {
 	Update(fn func())
 	// Quit shuts down the UI goroutine.
 	Quit()
+	// SetQuitKeys sets the key sequences that shut down the UI before any
+	// widget sees them. Calling it without keys disables quitting from the
+	// keyboard.
+	SetQuitKeys(keys ...string)
+	// SetQuitHandler sets a function that is asked to confirm a quit key.
+	// Returning false cancels the quit; the handler may call Quit later, e.g.
+	// from a confirmation dialog.
+	SetQuitHandler(fn func() bool)
 	// Repaint the UI
 	Repaint()
+	// RegisterCommand adds a named command to the command registry and binds
+	// it to its keys.
+	RegisterCommand(c Command)
+	// Commands returns the registered commands.
+	Commands() []Command
+	// RunCommand runs the registered command with the given name.
+	RunCommand(name string) error
+	// SetPaletteKey sets the key sequence that opens the command palette
+	// when the focused widget doesn't handle it.
+	SetPaletteKey(seq string)
+	// ApplyKeymap rebinds the registered commands according to a keymap.
+	ApplyKeymap(km Keymap) error
+	// LoadKeymap reads a JSON, TOML or YAML keymap file and applies it.
+	LoadKeymap(path string) error
+	// ReloadKeymap reads the last loaded keymap file again and applies it.
+	ReloadKeymap() error
+	// SetHelpKeys sets the key sequences that toggle the keybinding help.
+	SetHelpKeys(keys ...string)
+	// SetInspectorKey sets the key sequence that toggles the layout
+	// inspector, which outlines the widgets and describes their layout.
+	SetInspectorKey(seq string)
+	// LoadView reads a markup file, builds its widgets and shows them as
+	// the root widget, calling fn with the view first.
+	LoadView(path string, fn func(v *View)) error
+	// WatchView loads a markup file like LoadView and rebuilds the view
+	// whenever the file changes, until stop is called.
+	WatchView(path string, fn func(v *View)) (stop func(), err error)
+	// Bus returns the event bus used to pass application defined events
+	// between widgets.
+	Bus() *EventBus
 }
 
 //func New(root component.Widget) (component.UI, error) {
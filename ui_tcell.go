@@ -7,15 +7,44 @@ package termuix
 import (
 	tb "github.com/nsf/termbox-go"
 	"image"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
+// DefaultQuitKeys are the key sequences that shut down the UI unless
+// SetQuitKeys is called.
+var DefaultQuitKeys = []string{KeyCtrlQ, KeyCtrlC}
+
+// DefaultPaletteKey is the key sequence that opens the command palette unless
+// SetPaletteKey is called. The focused widget sees it first, and it opens the
+// palette only if the widget doesn't handle it.
+var DefaultPaletteKey = KeyCtrlP
+
+// quitSignals are the OS signals that shut down the UI and restore the
+// terminal.
+var quitSignals = []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP}
+
 var _ = &tcellUI{}
 
 type tcellUI struct {
 	painter *Painter
 	root    Widget
 
-	keybindings []*keybinding
+	keybindings  []*keybinding
+	commands     *commandRegistry
+	keymap       Keymap
+	keymapPath   string
+	paletteKey   string
+	helpKeys     []string
+	inspectorKey string
+
+	// overlays are drawn on top of root, the last one topmost. The topmost
+	// overlay receives all input.
+	overlays []Widget
+
+	quitKeys    []string
+	quitHandler func() bool
 
 	quit chan struct{}
 
@@ -24,27 +53,64 @@ type tcellUI struct {
 	kbFocus *kbFocusController
 
 	eventQueue chan Event
+
+	bus *EventBus
+
+	// size is the last known terminal size.
+	size image.Point
 }
 
 func newTcellUI(root Widget) (*tcellUI, error) {
 	p := NewPainter()
 	root.SetPainter(p)
-	return &tcellUI{
-		painter:     p,
-		root:        root,
-		keybindings: make([]*keybinding, 0),
-		quit:        make(chan struct{}, 1),
-		kbFocus:     &kbFocusController{chain: DefaultFocusChain},
-		eventQueue:  make(chan Event),
-	}, nil
+	bus := NewEventBus()
+	p.onUnmount = bus.Release
+	ui := &tcellUI{
+		painter:      p,
+		root:         root,
+		keybindings:  make([]*keybinding, 0),
+		commands:     newCommandRegistry(),
+		paletteKey:   DefaultPaletteKey,
+		helpKeys:     DefaultHelpKeys,
+		inspectorKey: DefaultInspectorKey,
+		quitKeys:     DefaultQuitKeys,
+		quit:         make(chan struct{}, 1),
+		kbFocus:      &kbFocusController{chain: DefaultFocusChain},
+		eventQueue:   make(chan Event),
+		bus:          bus,
+	}
+	ui.kbFocus.onFocusChanged = func(w Widget) {
+		scrollIntoView(ui.root, w)
+	}
+	ui.kbFocus.skip = func(w Widget) bool {
+		// Widgets outside the tree, such as the children hidden by a
+		// breakpoint, aren't shown.
+		shown, covered := belowModal(ui.root, w)
+		return !shown || covered
+	}
+	return ui, nil
 }
 
 func (ui *tcellUI) Repaint() {
-	ui.painter.Repaint(ui.root)
+	ui.painter.size = ui.size
+	ui.painter.Repaint(append([]Widget{ui.root}, ui.overlays...)...)
 }
 
 func (ui *tcellUI) SetWidget(w Widget) {
+	if ui.root != nil && ui.root != w {
+		ui.bus.Release(ui.root)
+	}
+	w.SetPainter(ui.painter)
 	ui.root = w
+	// Once running, the new root takes the size of the screen.
+	if ui.size != (image.Point{}) {
+		w.Resize(image.Point{}, ui.size)
+	}
+}
+
+// Bus returns the event bus of the UI.
+func (ui *tcellUI) Bus() *EventBus {
+	return ui.bus
 }
 
 func (ui *tcellUI) SetFocusChain(chain FocusChain) {
@@ -73,18 +139,34 @@ func (ui *tcellUI) ClearKeybindings() {
 	ui.keybindings = make([]*keybinding, 0)
 }
 
+// SetQuitKeys sets the key sequences that shut down the UI. With no keys the
+// UI can only be stopped with Quit or an OS signal.
+func (ui *tcellUI) SetQuitKeys(keys ...string) {
+	ui.quitKeys = keys
+}
+
+// SetQuitHandler sets the function that confirms a quit key press.
+func (ui *tcellUI) SetQuitHandler(fn func() bool) {
+	ui.quitHandler = fn
+}
+
+func (ui *tcellUI) isQuitKey(ev Event) bool {
+	for _, k := range ui.quitKeys {
+		if k == ev.ID {
+			return true
+		}
+	}
+	return false
+}
+
 func (ui *tcellUI) Run() error {
 	if err := tb.Init(); err != nil {
 		return err
 	}
 	tb.SetInputMode(tb.InputEsc | tb.InputMouse)
 	tb.SetOutputMode(tb.Output256)
-	failed := true
-	defer func() {
-		if failed {
-			tb.Close()
-		}
-	}()
+	// Restore the terminal however Run returns.
+	defer tb.Close()
 
 	if w := ui.kbFocus.chain.FocusDefault(); w != nil {
 		w.SetFocused(true)
@@ -92,21 +174,54 @@ func (ui *tcellUI) Run() error {
 	}
 	ui.screen.Clear()
 	ui.reSize(nil)
+	if w := ui.kbFocus.focusedWidget; w != nil {
+		scrollIntoView(ui.root, w)
+		ui.Repaint()
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, quitSignals...)
+	defer signal.Stop(sigs)
+
+	// Backends that don't report resize events are kept in sync by
+	// listening to the signal directly.
+	winch := make(chan os.Signal, 1)
+	if len(resizeSignals) > 0 {
+		signal.Notify(winch, resizeSignals...)
+		defer signal.Stop(winch)
+	}
+
 	uiEvents := PollEvents()
 	for {
 		select {
-		case e := <-uiEvents:
-			switch e.ID {
-			case KeyCtrlQ, "<C-c>":
-				return nil
-			default:
-				ui.handleEvent(e)
+		case <-ui.quit:
+			return nil
+		case sig := <-sigs:
+			logger.Printf("Received signal %v", sig)
+			return nil
+		case <-winch:
+			if size := ui.screen.Size(); size != ui.size {
+				ui.handleEvent(Event{
+					Type:    ResizeEvent,
+					ID:      "<Resize>",
+					Payload: Resize{Width: size.X, Height: size.Y},
+				})
 			}
+		case e := <-uiEvents:
+			ui.handleEvent(e)
 		//termui.Render(ui.root)
 		case e := <-ui.eventQueue:
 			ui.handleEvent(e)
-		case w := <-ui.painter.drawQueue:
-			ui.painter.Repaint(w)
+		case <-ui.bus.notify:
+			ui.bus.dispatch()
+		case <-ui.painter.wake:
+			ui.runPosted()
+		case <-ui.painter.drawQueue:
+			// Overlays may cover any widget, so the whole scene is drawn
+			// once for all pending requests.
+			ui.drainDrawQueue()
+			ui.layout()
+			ui.Repaint()
 		}
 	}
 }
@@ -119,15 +234,68 @@ func (ui *tcellUI) reSize(e *Event) {
 		payload := e.Payload.(Resize)
 		w, h = payload.Width, payload.Height
 	}
+	ui.size = image.Pt(w, h)
 	ui.root.Resize(image.Point{0, 0}, image.Pt(w, h))
+	for _, o := range ui.overlays {
+		ui.layoutOverlay(o)
+	}
 	ui.Repaint()
 }
 
 func (ui *tcellUI) handleEvent(ev Event) {
 	switch ev.Type {
 	case KeyboardEvent:
-		ui.root.DoEvent(ev)
+		if ui.isQuitKey(ev) {
+			if ui.quitHandler == nil || ui.quitHandler() {
+				ui.Quit()
+			}
+			return
+		}
+		if ui.inspectorKey != "" && ev.ID == ui.inspectorKey {
+			ui.toggleInspector()
+			return
+		}
+		if n := len(ui.overlays); n > 0 {
+			if top := ui.overlays[n-1]; !activateMnemonic(top, ev) {
+				top.DoEvent(ev)
+			}
+			return
+		}
+		// Help keys that type a character are left to the widgets first,
+		// so they can still be typed into an Input.
+		helpKey := ui.isHelpKey(ev)
+		if helpKey && !isCharKey(ev.ID) {
+			ui.toggleHelp()
+			return
+		}
+		// Like overlays, a modal layer keeps the keybindings from the
+		// widgets below it.
+		if !modalShown(ui.root) && ui.handleKeybindings(ev) {
+			return
+		}
+		if activateMnemonic(ui.root, ev) {
+			return
+		}
+		if ui.kbFocus.OnKeyEvent(ev) {
+			ui.Repaint()
+			return
+		}
+		if ui.root.DoEvent(ev) {
+			return
+		}
+		// The palette key is left to the widgets first, so that editors
+		// can still use it, like Ctrl-P in a TextArea.
+		switch {
+		case ui.paletteKey != "" && ev.ID == ui.paletteKey:
+			ui.showOverlay(newCommandPalette(ui))
+		case helpKey:
+			ui.toggleHelp()
+		}
 	case MouseEvent:
+		if n := len(ui.overlays); n > 0 {
+			ui.overlays[n-1].DoEvent(ev)
+			return
+		}
 		ui.root.DoEvent(ev)
 	case ResizeEvent:
 		ui.reSize(&ev)
@@ -135,16 +303,93 @@ func (ui *tcellUI) handleEvent(ev Event) {
 	case CallbackEvent:
 		// Gets stuck in a print loop when the logger is a widget.
 		//logger.Printf("Received callback event")
+		if fn, ok := ev.Payload.(func()); ok {
+			fn()
+		}
 	case PaintEvent:
 		logger.Printf("Received paint event")
 	}
 }
 
-// Quit signals to the UI to start shutting down.
+// handleKeybindings runs the handlers bound to the key of ev. It reports
+// whether any binding matched, in which case widgets don't see the key.
+func (ui *tcellUI) handleKeybindings(ev Event) bool {
+	var matched bool
+	for _, b := range ui.keybindings {
+		if b.match(ev) {
+			b.handler()
+			matched = true
+		}
+	}
+	return matched
+}
+
+// layout lays out the widgets invalidated since the last layout. Subtrees
+// that keep their size and weren't invalidated are left alone.
+func (ui *tcellUI) layout() {
+	ui.root.Resize(image.Point{}, ui.size)
+}
+
+// runPosted runs the functions queued by Post as callback events.
+func (ui *tcellUI) runPosted() {
+	for _, fn := range ui.painter.takePosted() {
+		ui.handleEvent(Event{Type: CallbackEvent, ID: "<Callback>", Payload: fn})
+	}
+}
+
+func (ui *tcellUI) drainDrawQueue() {
+	for {
+		select {
+		case <-ui.painter.drawQueue:
+		default:
+			return
+		}
+	}
+}
+
+// showOverlay draws w centered on top of the root widget and sends all input
+// to it until it is hidden.
+func (ui *tcellUI) showOverlay(w Widget) {
+	w.SetPainter(ui.painter)
+	ui.overlays = append(ui.overlays, w)
+	ui.layoutOverlay(w)
+	ui.Repaint()
+}
+
+// hideOverlay removes w from the overlays and clears the area it covered.
+func (ui *tcellUI) hideOverlay(w Widget) {
+	for i, o := range ui.overlays {
+		if o == w {
+			ui.overlays = append(ui.overlays[:i], ui.overlays[i+1:]...)
+			ui.painter.Fill(CellClear, w.GetOuter())
+			ui.Repaint()
+			return
+		}
+	}
+}
+
+// layoutOverlay centers w on the screen at its size hint, bounded by the
+// screen size.
+func (ui *tcellUI) layoutOverlay(w Widget) {
+	size := w.SizeHint()
+	if size.X > ui.size.X {
+		size.X = ui.size.X
+	}
+	if size.Y > ui.size.Y {
+		size.Y = ui.size.Y
+	}
+	pos := image.Pt((ui.size.X-size.X)/2, (ui.size.Y-size.Y)/2)
+	w.Resize(pos, size)
+}
+
+// Quit signals to the UI to start shutting down. The terminal is restored
+// when Run returns.
 func (ui *tcellUI) Quit() {
 	logger.Printf("Quitting")
-	tb.Close()
-	ui.quit <- struct{}{}
+	select {
+	case ui.quit <- struct{}{}:
+	default:
+	}
 }
 
 // Schedule an update of the UI, running the given
@@ -164,10 +409,10 @@ func (ui *tcellUI) Quit() {
 // is an error, and will deadlock.
 
 func (ui *tcellUI) Update(fn func()) {
-	//blk := make(chan struct{})
-	//ui.eventQueue <- callbackEvent{func() {
-	//	fn()
-	//	close(blk)
-	//}}
-	//<-blk
+	blk := make(chan struct{})
+	ui.eventQueue <- Event{Type: CallbackEvent, ID: "<Callback>", Payload: func() {
+		fn()
+		close(blk)
+	}}
+	<-blk
 }
@@ -0,0 +1,152 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termuix
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Validator checks the text of an Input, returning an error that describes
+// the problem if it isn't valid.
+type Validator func(text string) error
+
+// Mask reports whether text can be typed into an Input. Unlike a Validator,
+// it is given the text as it is being typed, so it must accept the beginning
+// of any valid text. Keystrokes making the text unacceptable are ignored.
+type Mask func(text string) bool
+
+// ValidateAll returns a Validator that runs vs in order and returns the first
+// error.
+func ValidateAll(vs ...Validator) Validator {
+	return func(text string) error {
+		for _, v := range vs {
+			if err := v(text); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateNotEmpty rejects empty text.
+func ValidateNotEmpty(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New("required")
+	}
+	return nil
+}
+
+// ValidateRegexp returns a Validator that rejects text not matching re with
+// the given message.
+func ValidateRegexp(re *regexp.Regexp, message string) Validator {
+	return func(text string) error {
+		if !re.MatchString(text) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// ValidateIntRange returns a Validator that rejects text that isn't an
+// integer between min and max.
+func ValidateIntRange(min, max int) Validator {
+	return func(text string) error {
+		n, err := strconv.Atoi(text)
+		if err != nil || n < min || n > max {
+			return fmt.Errorf("must be a number from %d to %d", min, max)
+		}
+		return nil
+	}
+}
+
+// ValidateIPv4 rejects text that isn't an IPv4 address in dotted decimal
+// form.
+func ValidateIPv4(text string) error {
+	parts := strings.Split(text, ".")
+	if len(parts) != 4 || !MaskIPv4(text) {
+		return errors.New("not an IP address")
+	}
+	for _, p := range parts {
+		if p == "" {
+			return errors.New("not an IP address")
+		}
+	}
+	return nil
+}
+
+// ValidateDate rejects text that isn't a date in the YYYY-MM-DD form.
+func ValidateDate(text string) error {
+	if _, err := time.Parse("2006-01-02", text); err != nil {
+		return errors.New("not a date (YYYY-MM-DD)")
+	}
+	return nil
+}
+
+// MaskDigits accepts decimal digits only.
+func MaskDigits(text string) bool {
+	for _, c := range text {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MaskIPv4 accepts up to four dot-separated numbers from 0 to 255.
+func MaskIPv4(text string) bool {
+	parts := strings.Split(text, ".")
+	if len(parts) > 4 {
+		return false
+	}
+	for i, p := range parts {
+		if p == "" && i < len(parts)-1 || len(p) > 3 || !MaskDigits(p) {
+			return false
+		}
+		if n, _ := strconv.Atoi(p); n > 255 {
+			return false
+		}
+	}
+	return true
+}
+
+// MaskDate accepts dates in the YYYY-MM-DD form.
+var MaskDate = NewPatternMask("9999-99-99")
+
+// NewPatternMask returns a Mask that accepts text following pattern, in
+// which '9' stands for a digit, 'a' for a letter, '*' for any character and
+// other characters for themselves.
+func NewPatternMask(pattern string) Mask {
+	pat := []rune(pattern)
+	return func(text string) bool {
+		runes := []rune(text)
+		if len(runes) > len(pat) {
+			return false
+		}
+		for i, c := range runes {
+			switch pat[i] {
+			case '9':
+				if c < '0' || c > '9' {
+					return false
+				}
+			case 'a':
+				if !unicode.IsLetter(c) {
+					return false
+				}
+			case '*':
+			default:
+				if c != pat[i] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
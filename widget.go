@@ -5,6 +5,7 @@
 package termuix
 
 import (
+	"fmt"
 	"github.com/cjbassi/gotop/colorschemes"
 	"image"
 	"sync"
@@ -36,6 +37,21 @@ const (
 	Expanding
 )
 
+// String returns the name of the size policy.
+func (p SizePolicy) String() string {
+	switch p {
+	case Preferred:
+		return "Preferred"
+	case Minimum:
+		return "Minimum"
+	case Maximum:
+		return "Maximum"
+	case Expanding:
+		return "Expanding"
+	}
+	return fmt.Sprintf("SizePolicy(%d)", int(p))
+}
+
 type Widget interface {
 	GetOuter() image.Rectangle
 	GetInner() image.Rectangle
@@ -57,6 +73,7 @@ type Widget interface {
 	Insert(i int, w Widget)
 	Remove(i int)
 	Length() int
+	Children() []Widget
 	//SetBorder(enabled bool)
 	//SetTitle(title string)
 	SetText(text string)
@@ -66,6 +83,9 @@ type Widget interface {
 	SizeHint() image.Point
 	Resize(pos image.Point, size image.Point)
 	ReLayout() //重新布局
+	// Invalidate drops the cached size hints of the widget and its
+	// ancestors, and has them laid out again.
+	Invalidate()
 	sync.Locker
 }
 
@@ -91,6 +111,9 @@ type WidgetBase struct {
 	parent      Widget
 	painter     *Painter
 	px, py      int //用于记录当前的点输出点
+	constraints Constraints
+	violations  []ConstraintViolation
+	hints       layoutCache
 
 	sync.Mutex
 }
@@ -136,6 +159,24 @@ func (s *WidgetBase) rePaint(w Widget) {
 	//p.Repaint(w)
 }
 
+// Refresh asks the UI to draw the widget again, e.g. after an event changed
+// what it shows.
+func (s *WidgetBase) Refresh() {
+	s.rePaint(s)
+}
+
+// Post runs fn on the UI goroutine, like the handlers of events. Unlike
+// UI.Update it doesn't wait for fn, so it may be called from any goroutine,
+// event handlers included. A widget not shown by a UI runs fn right away.
+func (s *WidgetBase) Post(fn func()) {
+	p := s.GetPainter()
+	if p == nil {
+		fn()
+		return
+	}
+	p.post(fn)
+}
+
 func (s *WidgetBase) drawSubWidget() {
 	for _, v := range s.children {
 		v.Draw()
@@ -148,8 +189,7 @@ func (s *WidgetBase) drawClear() {
 		return
 	}
 	outer := s.GetOuter()
-	min := s.parent.GetInnerRealPos().Min
-	fillRect := outer.Add(min)
+	fillRect := outer.Add(s.GetParentMin())
 	p.Fill(Cell{' ', s.style}, fillRect)
 }
 
@@ -223,6 +263,7 @@ func (w *WidgetBase) SetWidth(width int) {
 	} else {
 		w.sizePolicyX = Expanding
 	}
+	w.Invalidate()
 }
 
 // SetHeight returns whether the widget is active.
@@ -233,18 +274,17 @@ func (w *WidgetBase) SetHeight(height int) {
 	} else {
 		w.sizePolicyY = Expanding
 	}
+	w.Invalidate()
 }
 
+// GetPainter returns the painter of the widget, or of its closest ancestor
+// having one. It isn't cached, so that Post can look it up from any
+// goroutine.
 func (w *WidgetBase) GetPainter() *Painter {
-	if w.painter == nil {
-		if w.parent != nil {
-			w.painter = w.parent.GetPainter()
-			return w.painter
-		}
-		return nil
-	} else {
-		return w.painter
+	if w.painter == nil && w.parent != nil {
+		return w.parent.GetPainter()
 	}
+	return w.painter
 }
 
 func (w *WidgetBase) SetPainter(p *Painter) {
@@ -255,12 +295,14 @@ func (w *WidgetBase) SetPainter(p *Painter) {
 func (s *WidgetBase) Append(w Widget) {
 	s.children = append(s.children, w)
 	w.SetParent(s)
+	s.Invalidate()
 }
 
 // Prepend adds the given widget at the start of the Box.
 func (s *WidgetBase) Prepend(w Widget) {
 	s.children = append([]Widget{w}, s.children...)
 	w.SetParent(s)
+	s.Invalidate()
 }
 
 // Insert adds the widget into the Box at a given index.
@@ -273,6 +315,7 @@ func (s *WidgetBase) Insert(i int, w Widget) {
 	copy(s.children[i+1:], s.children[i:])
 	s.children[i] = w
 	w.SetParent(s)
+	s.Invalidate()
 }
 
 // Remove deletes the widget from the Box at a given index.
@@ -281,7 +324,13 @@ func (s *WidgetBase) Remove(i int) {
 		return
 	}
 
+	w := s.children[i]
 	s.children = append(s.children[:i], s.children[i+1:]...)
+	w.SetParent(nil)
+	s.Invalidate()
+	if p := s.GetPainter(); p != nil && p.onUnmount != nil {
+		p.onUnmount(w)
+	}
 }
 
 // Length returns the number of items in the box.
@@ -289,6 +338,39 @@ func (s *WidgetBase) Length() int {
 	return len(s.children)
 }
 
+// Children returns the widgets contained in the Box. The returned slice must
+// not be modified.
+func (s *WidgetBase) Children() []Widget {
+	return s.children
+}
+
+// realOuter returns the outer rectangle of w in screen coordinates.
+func realOuter(w Widget) image.Rectangle {
+	return w.GetOuter().Add(w.GetInnerRealPos().Min.Sub(w.GetInner().Min))
+}
+
+// clickedAt returns the screen position of a left click on w, and whether e
+// is such a click.
+func clickedAt(w Widget, e Event) (image.Point, bool) {
+	if e.Type != MouseEvent || e.ID != "<MouseLeft>" {
+		return image.Point{}, false
+	}
+	m := e.Payload.(Mouse)
+	pt := image.Pt(m.X, m.Y)
+	return pt, !m.Drag && pt.In(realOuter(w))
+}
+
+// walkWidgets calls fn for w and each of its descendants in depth-first
+// order. Returning false from fn skips the descendants of that widget.
+func walkWidgets(w Widget, fn func(w Widget) bool) {
+	if w == nil || !fn(w) {
+		return
+	}
+	for _, child := range w.Children() {
+		walkWidgets(child, fn)
+	}
+}
+
 // Alignment returns the current alignment of the Box.
 func (s *WidgetBase) LayoutMode() LayoutMode {
 	return s.layout
@@ -304,7 +386,7 @@ func (s *WidgetBase) MinSizeHint() image.Point {
 	var minSize image.Point
 	if s.LayoutMode() == Horizontal {
 		for _, child := range s.children {
-			size := child.MinSizeHint()
+			size := minSizeHintOf(child)
 			minSize.X += size.X
 			if size.Y > minSize.Y {
 				minSize.Y = size.Y
@@ -312,17 +394,18 @@ func (s *WidgetBase) MinSizeHint() image.Point {
 		}
 	} else {
 		for _, child := range s.children {
-			size := child.MinSizeHint()
+			size := minSizeHintOf(child)
 			minSize.Y += size.Y
 			if size.X > minSize.X {
 				minSize.X = size.X
 			}
 		}
 	}
+	minSize = minSize.Add(s.FrameSize())
 	if s.size.X > 0 {
 		minSize.X = s.size.X
 	}
-	if s.size.Y == 0 {
+	if s.size.Y > 0 {
 		minSize.Y = s.size.Y
 	}
 	return minSize
@@ -333,7 +416,7 @@ func (s *WidgetBase) SizeHint() image.Point {
 	var sizeHint image.Point
 
 	for _, child := range s.children {
-		size := child.SizeHint()
+		size := sizeHintOf(child)
 		if s.LayoutMode() == Horizontal {
 			sizeHint.X += size.X
 			if size.Y > sizeHint.Y {
@@ -347,18 +430,18 @@ func (s *WidgetBase) SizeHint() image.Point {
 		}
 	}
 
-	return sizeHint
+	return sizeHint.Add(s.FrameSize())
 }
 
 // Resize recursively updates the size of the Box and all the widgets it
-// contains. This is a potentially expensive operation and should be invoked
-// with restraint.
+// contains. The children are only laid out again if the size or position of
+// the Box changed or its layout was invalidated.
 //
 // Resize is called by the layout engine and is not intended to be used by end
 // users.
 
 func (s *WidgetBase) Resize(pos image.Point, size image.Point) {
-	if size.X != s.Width || size.Y != s.Height || s.MarginLeft != pos.X || s.MarginTop != pos.Y {
+	if s.needsLayout(pos, size) {
 		s.Width = size.X
 		s.Height = size.Y
 		s.X = pos.X
@@ -382,6 +465,10 @@ func (s *WidgetBase) ReLayout() {
 }
 
 func (s *WidgetBase) layoutChildren(pos image.Point, size image.Point) {
+	if hasConstraints(s.children) {
+		s.layoutConstrained(pos, size)
+		return
+	}
 	space := doLayout(s.children, dim(s.LayoutMode(), size), s.LayoutMode())
 	for i, sp := range space {
 		switch s.LayoutMode() {
@@ -395,6 +482,28 @@ func (s *WidgetBase) layoutChildren(pos image.Point, size image.Point) {
 	}
 }
 
+// layoutConstrained lays out children some of which have size constraints.
+// Children smaller than the Box across the layout direction are placed at its
+// start.
+func (s *WidgetBase) layoutConstrained(pos image.Point, size image.Point) {
+	a := s.LayoutMode()
+	cross := size.Y
+	if a == Vertical {
+		cross = size.X
+	}
+	sizes, crosses := solveConstraints(s.children, dim(a, size), cross, a)
+	for i, child := range s.children {
+		switch a {
+		case Horizontal:
+			child.Resize(pos, image.Pt(sizes[i], crosses[i]))
+			pos.X += sizes[i]
+		case Vertical:
+			child.Resize(pos, image.Pt(crosses[i], sizes[i]))
+			pos.Y += sizes[i]
+		}
+	}
+}
+
 func dim(a LayoutMode, pt image.Point) int {
 	if a == Horizontal {
 		return pt.X
@@ -410,121 +519,35 @@ func alignedSizePolicy(a LayoutMode, w Widget) SizePolicy {
 	return vpol
 }
 
-func doLayout(ws []Widget, space int, a LayoutMode) []int {
-	sizes := make([]int, len(ws))
-
-	if len(sizes) == 0 {
-		return sizes
-	}
-
-	remaining := space
-
-	// Distribute MinSizeHint
-	for {
-		var changed bool
-		for i, sz := range sizes {
-			minSize := ws[i].MinSizeHint()
-			if sz < dim(a, minSize) {
-				sizes[i] = sz + 1
-				remaining--
-				if remaining <= 0 {
-					goto Resize
-				}
-				changed = true
-			}
-		}
-		if !changed {
-			break
-		}
-	}
-
-	// Distribute Minimum
-	for {
-		var changed bool
-		for i, sz := range sizes {
-			p := alignedSizePolicy(a, ws[i])
-			if p == Minimum && sz < dim(a, ws[i].SizeHint()) {
-				sizes[i] = sz + 1
-				remaining--
-				if remaining <= 0 {
-					goto Resize
-				}
-				changed = true
-			}
-		}
-		if !changed {
-			break
-		}
+// distribute splits total into parts proportional to weights. The parts add
+// up to total; the cells lost to rounding go to the largest remainders.
+func distribute(total int, weights []float64) []int {
+	parts := make([]int, len(weights))
+	var sum float64
+	for _, w := range weights {
+		sum += w
 	}
-
-	// Distribute Preferred
-	for {
-		var changed bool
-		for i, sz := range sizes {
-			p := alignedSizePolicy(a, ws[i])
-			if (p == Preferred || p == Maximum) && sz < dim(a, ws[i].SizeHint()) {
-				sizes[i] = sz + 1
-				remaining--
-				if remaining <= 0 {
-					goto Resize
-				}
-				changed = true
-			}
-		}
-		if !changed {
-			break
-		}
+	if total <= 0 || sum <= 0 {
+		return parts
 	}
 
-	// Distribute Expanding
-	for {
-		var changed bool
-		for i, sz := range sizes {
-			p := alignedSizePolicy(a, ws[i])
-			if p == Expanding {
-				sizes[i] = sz + 1
-				remaining--
-				if remaining <= 0 {
-					goto Resize
-				}
-				changed = true
-			}
-		}
-		if !changed {
-			break
-		}
+	rest := total
+	fracs := make([]float64, len(weights))
+	for i, w := range weights {
+		exact := float64(total) * w / sum
+		parts[i] = int(exact)
+		fracs[i] = exact - float64(parts[i])
+		rest -= parts[i]
 	}
-
-	// Distribute remaining space
-	for {
-		min := maxInt
-		for i, s := range sizes {
-			p := alignedSizePolicy(a, ws[i])
-			if (p == Preferred || p == Minimum) && s <= min {
-				min = s
-			}
-		}
-		var changed bool
-		for i, sz := range sizes {
-			if sz != min {
-				continue
-			}
-			p := alignedSizePolicy(a, ws[i])
-			if p == Preferred || p == Minimum {
-				sizes[i] = sz + 1
-				remaining--
-				if remaining <= 0 {
-					goto Resize
-				}
-				changed = true
+	for ; rest > 0; rest-- {
+		best := 0
+		for i := range fracs {
+			if fracs[i] > fracs[best] {
+				best = i
 			}
 		}
-		if !changed {
-			break
-		}
+		parts[best]++
+		fracs[best] = -1
 	}
-
-Resize:
-
-	return sizes
+	return parts
 }
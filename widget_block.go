@@ -19,6 +19,16 @@ type widgetBlock struct {
 	TitleStyle                                           Style
 }
 
+// blocked is implemented by widgets built on a widgetBlock, giving access to
+// their margins, padding and title.
+type blocked interface {
+	block() *widgetBlock
+}
+
+func (s *widgetBlock) block() *widgetBlock {
+	return s
+}
+
 // GetRect implements the Drawable interface.
 func (s *widgetBlock) GetOuter() image.Rectangle {
 	return image.Rect(s.X, s.Y, s.X+s.Width, s.Y+s.Height)
@@ -76,6 +86,29 @@ func (s *widgetBlock) GetInner() image.Rectangle {
 	return image.Rect(int(x), int(y), int(x)+w, int(y)+h)
 }
 
+// FrameSize returns the space taken by the border and the padding, i.e. the
+// difference between the outer and the inner size.
+func (s *widgetBlock) FrameSize() image.Point {
+	var size image.Point
+	if s.Border {
+		if s.BorderLeft {
+			size.X++
+		}
+		if s.BorderRight {
+			size.X++
+		}
+		if s.BorderTop {
+			size.Y++
+		}
+		if s.BorderBottom {
+			size.Y++
+		}
+	}
+	size.X += s.PaddingLeft + s.PaddingRight
+	size.Y += s.PaddingTop + s.PaddingBottom
+	return size
+}
+
 func (s *widgetBlock) SetRect(x, y, w, h int) {
 	s.X = x
 	s.Y = y
@@ -38,23 +38,52 @@ func NewBarChart() *BarChart {
 	}
 }
 
-func (self *BarChart) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// barChartHeight is the height of the bars in the size hint of bar charts.
+const barChartHeight = 8
+
+// barsWidth returns the width taken by n bars.
+func barsWidth(n, width, gap int) int {
+	if n == 0 {
+		return 0
+	}
+	return n*width + (n-1)*gap
+}
+
+// SizeHint returns the size showing every bar.
+func (self *BarChart) SizeHint() image.Point {
+	width := barsWidth(len(self.Data), self.BarWidth, self.BarGap)
+	return image.Pt(width, barChartHeight+1).Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing a single bar with its label.
+func (self *BarChart) MinSizeHint() image.Point {
+	return image.Pt(self.BarWidth, 3).Add(self.FrameSize())
+}
+
+func (self *BarChart) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
 
 	maxVal := self.MaxVal
 	if maxVal == 0 {
 		maxVal, _ = GetMaxFloat64FromSlice(self.Data)
 	}
 
-	barXCoordinate := self.Inner.Min.X
+	barXCoordinate := inner.Min.X
 
 	for i, data := range self.Data {
 		// draw bar
-		height := int((data / maxVal) * float64(self.Inner.Dy()-1))
-		for x := barXCoordinate; x < MinInt(barXCoordinate+self.BarWidth, self.Inner.Max.X); x++ {
-			for y := self.Inner.Max.Y - 2; y > (self.Inner.Max.Y-2)-height; y-- {
+		height := int((data / maxVal) * float64(inner.Dy()-1))
+		for x := barXCoordinate; x < MinInt(barXCoordinate+self.BarWidth, inner.Max.X); x++ {
+			for y := inner.Max.Y - 2; y > (inner.Max.Y-2)-height; y-- {
 				c := NewCell(' ', NewStyle(ColorClear, SelectColor(self.BarColors, i)))
-				buf.SetCell(c, image.Pt(x, y))
+				p.SetCell(c, image.Pt(x, y))
 			}
 		}
 
@@ -63,24 +92,24 @@ func (self *BarChart) Draw(buf *Buffer) {
 			labelXCoordinate := barXCoordinate +
 				int((float64(self.BarWidth) / 2)) -
 				int((float64(rw.StringWidth(self.Labels[i])) / 2))
-			buf.SetString(
+			p.SetString(
 				self.Labels[i],
 				SelectStyle(self.LabelStyles, i),
-				image.Pt(labelXCoordinate, self.Inner.Max.Y-1),
+				image.Pt(labelXCoordinate, inner.Max.Y-1),
 			)
 		}
 
 		// draw number
 		numberXCoordinate := barXCoordinate + int((float64(self.BarWidth) / 2))
-		if numberXCoordinate <= self.Inner.Max.X {
-			buf.SetString(
+		if numberXCoordinate <= inner.Max.X {
+			p.SetString(
 				self.NumFormatter(data),
 				NewStyle(
 					SelectStyle(self.NumStyles, i+1).Fg,
 					SelectColor(self.BarColors, i),
 					SelectStyle(self.NumStyles, i+1).Modifier,
 				),
-				image.Pt(numberXCoordinate, self.Inner.Max.Y-2),
+				image.Pt(numberXCoordinate, inner.Max.Y-2),
 			)
 		}
 
@@ -6,14 +6,14 @@ package widgets
 
 import (
 	"github.com/cjbassi/gotop/colorschemes"
-	_ "github.com/cjbassi/gotop/colorschemes"
-	ui "github.com/gizak/termui/v3"
+
+	. "github.com/thzll/termuix"
 )
 
 var colorscheme = colorschemes.Default
 
 func SetDefaultTermuiColors() {
-	ui.Theme.Default = ui.NewStyle(ui.Color(colorscheme.Fg), ui.Color(colorscheme.Bg))
-	ui.Theme.Block.Title = ui.NewStyle(ui.Color(colorscheme.BorderLabel), ui.Color(colorscheme.Bg))
-	ui.Theme.Block.Border = ui.NewStyle(ui.Color(colorscheme.BorderLine), ui.Color(colorscheme.Bg))
+	Theme.Default = NewStyle(Color(colorscheme.Fg), Color(colorscheme.Bg))
+	Theme.Block.Title = NewStyle(Color(colorscheme.BorderLabel), Color(colorscheme.Bg))
+	Theme.Block.Border = NewStyle(Color(colorscheme.BorderLine), Color(colorscheme.Bg))
 }
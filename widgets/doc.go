@@ -0,0 +1,13 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// Package widgets provides the termui widgets, drawn by termuix.
+//
+// The widgets keep what they show in exported fields, as in termui. The
+// size hints of a widget are cached until it is invalidated, so after
+// changing a field that affects its size, such as Rows, Text or Data, call
+// Invalidate on the widget, or use a setter like SetRows or SetText, which
+// does it. Rows appended to a DataSource that is a ChangeNotifier invalidate
+// the List or Table showing it.
+package widgets
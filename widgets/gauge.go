@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"image"
 
+	rw "github.com/mattn/go-runewidth"
+
 	. "github.com/thzll/termuix"
 )
 
@@ -27,31 +29,51 @@ func NewGauge() *Gauge {
 	}
 }
 
-func (self *Gauge) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+func (self *Gauge) label() string {
+	if self.Label == "" {
+		return fmt.Sprintf("%d%%", self.Percent)
+	}
+	return self.Label
+}
+
+// SizeHint returns a one line bar wide enough for the label.
+func (self *Gauge) SizeHint() image.Point {
+	return image.Pt(rw.StringWidth(self.label())+2, 1).Add(self.FrameSize())
+}
+
+// MinSizeHint returns a single cell.
+func (self *Gauge) MinSizeHint() image.Point {
+	return image.Pt(1, 1).Add(self.FrameSize())
+}
 
-	label := self.Label
-	if label == "" {
-		label = fmt.Sprintf("%d%%", self.Percent)
+func (self *Gauge) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
 	}
+	inner := self.GetInnerRealPos()
+	label := self.label()
 
 	// plot bar
-	barWidth := int((float64(self.Percent) / 100) * float64(self.Inner.Dx()))
-	buf.Fill(
+	barWidth := int((float64(self.Percent) / 100) * float64(inner.Dx()))
+	p.Fill(
 		NewCell(' ', NewStyle(ColorClear, self.BarColor)),
-		image.Rect(self.Inner.Min.X, self.Inner.Min.Y, self.Inner.Min.X+barWidth, self.Inner.Max.Y),
+		image.Rect(inner.Min.X, inner.Min.Y, inner.Min.X+barWidth, inner.Max.Y),
 	)
 
 	// plot label
-	labelXCoordinate := self.Inner.Min.X + (self.Inner.Dx() / 2) - int(float64(len(label))/2)
-	labelYCoordinate := self.Inner.Min.Y + ((self.Inner.Dy() - 1) / 2)
-	if labelYCoordinate < self.Inner.Max.Y {
+	labelXCoordinate := inner.Min.X + (inner.Dx() / 2) - int(float64(len(label))/2)
+	labelYCoordinate := inner.Min.Y + ((inner.Dy() - 1) / 2)
+	if labelYCoordinate < inner.Max.Y {
 		for i, char := range label {
 			style := self.LabelStyle
-			if labelXCoordinate+i+1 <= self.Inner.Min.X+barWidth {
+			if labelXCoordinate+i+1 <= inner.Min.X+barWidth {
 				style = NewStyle(self.BarColor, ColorClear, ModifierReverse)
 			}
-			buf.SetCell(NewCell(char, style), image.Pt(labelXCoordinate+i, labelYCoordinate))
+			p.SetCell(NewCell(char, style), image.Pt(labelXCoordinate+i, labelYCoordinate))
 		}
 	}
 }
@@ -8,7 +8,7 @@ import (
 	"image"
 	"image/color"
 
-	. "github.com/gizak/termui/v3"
+	. "github.com/thzll/termuix"
 )
 
 type Image struct {
@@ -27,15 +27,40 @@ func NewImage(img image.Image) *Image {
 	}
 }
 
-func (self *Image) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// SizeHint returns the size showing the image at full resolution: one cell
+// per pixel, or per 2x2 pixels in monochrome.
+func (self *Image) SizeHint() image.Point {
+	if self.Image == nil {
+		return self.FrameSize()
+	}
+	size := self.Image.Bounds().Size()
+	if self.Monochrome {
+		size = size.Div(2)
+	}
+	return size.Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing a single cell.
+func (self *Image) MinSizeHint() image.Point {
+	return image.Pt(1, 1).Add(self.FrameSize())
+}
+
+func (self *Image) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
 
 	if self.Image == nil {
 		return
 	}
 
-	bufWidth := self.Inner.Dx()
-	bufHeight := self.Inner.Dy()
+	bufWidth := inner.Dx()
+	bufHeight := inner.Dy()
 	imageWidth := self.Image.Bounds().Dx()
 	imageHeight := self.Image.Bounds().Dy()
 
@@ -72,9 +97,9 @@ func (self *Image) Draw(buf *Buffer) {
 					(2*by+1)*imageHeight/bufHeight/2,
 					(2*by+2)*imageHeight/bufHeight/2,
 				)
-				buf.SetCell(
+				p.SetCell(
 					NewCell(blocksChar(ul, ur, ll, lr, self.MonochromeThreshold, self.MonochromeInvert)),
-					image.Pt(self.Inner.Min.X+bx, self.Inner.Min.Y+by),
+					image.Pt(inner.Min.X+bx, inner.Min.Y+by),
 				)
 			}
 		}
@@ -93,9 +118,9 @@ func (self *Image) Draw(buf *Buffer) {
 					by*imageHeight/bufHeight,
 					(by+1)*imageHeight/bufHeight,
 				)
-				buf.SetCell(
+				p.SetCell(
 					NewCell(c.ch(), NewStyle(c.fgColor(), ColorBlack)),
-					image.Pt(self.Inner.Min.X+bx, self.Inner.Min.Y+by),
+					image.Pt(inner.Min.X+bx, inner.Min.Y+by),
 				)
 			}
 		}
@@ -6,12 +6,34 @@ package widgets
 
 import (
 	"image"
+	"sort"
+	"time"
+	"unicode"
 
 	rw "github.com/mattn/go-runewidth"
 
-	. "github.com/gizak/termui/v3"
+	. "github.com/thzll/termuix"
 )
 
+// doubleClickTime is the longest time between the two clicks activating a
+// row.
+const doubleClickTime = 400 * time.Millisecond
+
+// filterChunk is the number of rows of a DataSource fetched at once when
+// filtering them.
+const filterChunk = 1024
+
+// List shows rows of styled text, one of which is under the cursor. When
+// focused, it moves the cursor with the arrow keys, and typing "/" starts a
+// filter that hides the rows not containing the typed text. Enter or a
+// double-click activates the row under the cursor.
+//
+// With MultiSelect set, Space selects or unselects the row under the cursor,
+// and Shift with the arrow keys selects the rows the cursor moves over.
+// Otherwise the row under the cursor is the selection.
+//
+// The rows come from Rows, or from a DataSource set with SetSource, of which
+// only the rows drawn are fetched.
 type List struct {
 	Block
 	Rows             []string
@@ -20,82 +42,655 @@ type List struct {
 	SelectedRow      int
 	topRow           int
 	SelectedRowStyle Style
+
+	MultiSelect    bool
+	MarkedRowStyle Style
+	// MatchStyle is merged into the characters matching the filter.
+	MatchStyle  Style
+	FilterStyle Style
+
+	marked map[int]bool
+	// anchor is the row where a range selected with Shift starts.
+	anchor int
+
+	filter    []rune
+	filtering bool
+
+	source       DataSource
+	cancelSource func()
+	// knownLen is the number of rows of the source before its last change.
+	knownLen int
+	// shown caches the rows of the source matching the filter, out of the
+	// first shownLen rows.
+	shown      []int
+	shownLen   int
+	shownValid bool
+
+	lastClick    time.Time
+	lastClickRow int
+
+	onSelectionChanged func(*List)
+	onActivated        func(*List)
 }
 
 func NewList() *List {
 	return &List{
 		Block:            *NewBlock(),
 		TextStyle:        Theme.List.Text,
-		SelectedRowStyle: Theme.List.Text,
+		SelectedRowStyle: Theme.List.Selected,
+		MarkedRowStyle:   Theme.List.Marked,
+		MatchStyle:       Theme.List.Match,
+		FilterStyle:      Theme.List.Filter,
+		marked:           make(map[int]bool),
+		lastClickRow:     -1,
+	}
+}
+
+// OnSelectionChanged sets the function called when the user changes the
+// selection.
+func (self *List) OnSelectionChanged(fn func(*List)) {
+	self.onSelectionChanged = fn
+}
+
+// OnActivated sets the function called when the row under the cursor is
+// activated with Enter or a double-click.
+func (self *List) OnActivated(fn func(*List)) {
+	self.onActivated = fn
+}
+
+// SetSource has the list show the rows of src instead of Rows. If src is a
+// ChangeNotifier, the list is drawn again after each change, and a cursor on
+// the last row stays on the last row as rows are appended. Setting nil shows
+// Rows again.
+func (self *List) SetSource(src DataSource) {
+	self.Lock()
+	defer self.Unlock()
+	if self.cancelSource != nil {
+		self.cancelSource()
+		self.cancelSource = nil
+	}
+	self.source = src
+	self.shownValid = false
+	if src != nil {
+		self.knownLen = src.Len()
+		self.cancelSource = subscribe(src, self.Post, self.sourceChanged)
+	}
+	self.Invalidate()
+}
+
+// SetRows sets the rows shown when there is no DataSource.
+func (self *List) SetRows(rows []string) {
+	self.Rows = rows
+	self.Invalidate()
+	self.Refresh()
+}
+
+// Source returns the DataSource of the list, or nil if it shows Rows.
+func (self *List) Source() DataSource {
+	return self.source
+}
+
+// sourceChanged keeps the cursor on the last row when rows are appended, and
+// draws the list again. Only the appended rows are filtered; other changes
+// have all rows filtered again when the list is drawn. It runs on the UI
+// goroutine.
+func (self *List) sourceChanged(c Change) {
+	self.Lock()
+	if self.source == nil {
+		self.Unlock()
+		return
+	}
+	n := self.source.Len()
+	if c == RowsAppended && n >= self.shownLen {
+		if self.shownValid && len(self.filter) > 0 {
+			self.shown = append(self.shown, self.filterRows(self.shownLen, n)...)
+			self.shownLen = n
+		}
+	} else {
+		self.shownValid = false
+	}
+	if self.SelectedRow >= self.knownLen-1 {
+		self.SelectedRow = n - 1
+	}
+	self.SelectedRow = MaxInt(MinInt(self.SelectedRow, n-1), 0)
+	self.knownLen = n
+	self.Unlock()
+	self.Invalidate()
+	self.Refresh()
+}
+
+// rowCount returns the number of rows.
+func (self *List) rowCount() int {
+	if self.source != nil {
+		return self.source.Len()
+	}
+	return len(self.Rows)
+}
+
+// rowsIn returns the rows from index from up to, but not including, to.
+func (self *List) rowsIn(from, to int) []string {
+	if self.source == nil {
+		from = MaxInt(from, 0)
+		to = MinInt(to, len(self.Rows))
+		if from >= to {
+			return nil
+		}
+		return self.Rows[from:to]
+	}
+	rows := self.source.Rows(from, to)
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		if len(row) > 0 {
+			lines[i] = row[0]
+		}
+	}
+	return lines
+}
+
+// row returns the row at index i.
+func (self *List) row(i int) string {
+	if rows := self.rowsIn(i, i+1); len(rows) > 0 {
+		return rows[0]
+	}
+	return ""
+}
+
+// Selection returns the indices of the selected rows in ascending order.
+func (self *List) Selection() []int {
+	if !self.MultiSelect {
+		if self.SelectedRow < 0 || self.SelectedRow >= self.rowCount() {
+			return nil
+		}
+		return []int{self.SelectedRow}
+	}
+	var rows []int
+	for row := range self.marked {
+		if row < self.rowCount() {
+			rows = append(rows, row)
+		}
+	}
+	sort.Ints(rows)
+	return rows
+}
+
+// IsSelected returns whether the row at index i is selected.
+func (self *List) IsSelected(i int) bool {
+	if !self.MultiSelect {
+		return i == self.SelectedRow
+	}
+	return self.marked[i]
+}
+
+// SetSelected selects or unselects the row at index i. Without MultiSelect,
+// selecting a row moves the cursor to it.
+func (self *List) SetSelected(i int, selected bool) {
+	if i < 0 || i >= self.rowCount() {
+		return
+	}
+	if !self.MultiSelect {
+		if selected {
+			self.SelectedRow = i
+		}
+		return
+	}
+	if selected {
+		self.marked[i] = true
+	} else {
+		delete(self.marked, i)
+	}
+}
+
+// ClearSelection unselects all rows. Without MultiSelect, the row under the
+// cursor stays selected.
+func (self *List) ClearSelection() {
+	self.marked = make(map[int]bool)
+}
+
+// Filter returns the text the rows are filtered by.
+func (self *List) Filter() string {
+	return string(self.filter)
+}
+
+// SetFilter shows only the rows containing text, ignoring case and styles.
+// The cursor moves to the next shown row if its row is hidden.
+func (self *List) SetFilter(text string) {
+	self.filter = []rune(text)
+	self.shownValid = false
+	self.keepCursorShown()
+}
+
+// match returns the index of the first of the cells of a row matching the
+// filter, or -1 if the row doesn't contain it.
+func (self *List) match(cells []Cell) int {
+	for i := 0; i+len(self.filter) <= len(cells); i++ {
+		j := 0
+		for j < len(self.filter) && unicode.ToLower(cells[i+j].Rune) == unicode.ToLower(self.filter[j]) {
+			j++
+		}
+		if j == len(self.filter) {
+			return i
+		}
+	}
+	return -1
+}
+
+// listView maps the positions of the rows shown by a List to the rows.
+type listView struct {
+	// rows holds the rows matching the filter, if filtered.
+	rows     []int
+	filtered bool
+	n        int
+}
+
+func (v listView) len() int {
+	if v.filtered {
+		return len(v.rows)
+	}
+	return v.n
+}
+
+// row returns the row shown at position pos.
+func (v listView) row(pos int) int {
+	if v.filtered {
+		return v.rows[pos]
+	}
+	return pos
+}
+
+// pos returns the position of the first shown row at or after row, limited
+// to the shown rows.
+func (v listView) pos(row int) int {
+	if v.filtered {
+		row = sort.SearchInts(v.rows, row)
+	}
+	return MaxInt(0, MinInt(row, v.len()-1))
+}
+
+// view returns the rows matching the filter. For a DataSource, they are
+// cached until the filter changes or the rows are reset, and appended rows
+// are filtered as they come.
+func (self *List) view() listView {
+	n := self.rowCount()
+	if len(self.filter) == 0 {
+		return listView{n: n}
+	}
+	if self.source == nil || !self.shownValid {
+		self.shown = self.filterRows(0, n)
+		self.shownLen = n
+		self.shownValid = self.source != nil
+	}
+	return listView{rows: self.shown, filtered: true}
+}
+
+// filterRows returns the rows from index from up to, but not including, to
+// that match the filter.
+func (self *List) filterRows(from, to int) []int {
+	shown := make([]int, 0)
+	for ; from < to; from += filterChunk {
+		for i, line := range self.rowsIn(from, MinInt(from+filterChunk, to)) {
+			if self.match(ParseStyles(line, self.TextStyle)) >= 0 {
+				shown = append(shown, from+i)
+			}
+		}
 	}
+	return shown
 }
 
-func (self *List) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// keepCursorShown moves the cursor to the next shown row if its row is
+// hidden by the filter.
+func (self *List) keepCursorShown() {
+	v := self.view()
+	if v.len() == 0 {
+		return
+	}
+	if row := v.row(v.pos(self.SelectedRow)); row != self.SelectedRow {
+		self.moveCursor(row, false)
+	}
+}
 
-	point := self.Inner.Min
+// showsFilter returns whether the last row of the list shows the filter.
+func (self *List) showsFilter() bool {
+	return self.filtering || len(self.filter) > 0
+}
+
+// pageRows returns the number of rows the list shows at once.
+func (self *List) pageRows() int {
+	rows := self.GetInner().Dy()
+	if self.showsFilter() {
+		rows--
+	}
+	return MaxInt(rows, 1)
+}
+
+func (self *List) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
+	if self.showsFilter() && inner.Dy() > 1 {
+		prompt := image.Rect(inner.Min.X, inner.Max.Y-1, inner.Max.X, inner.Max.Y)
+		p.PushClip(prompt)
+		p.SetString("/"+string(self.filter), self.FilterStyle, prompt.Min)
+		if self.filtering && self.IsFocused() {
+			p.DrawCursor(prompt.Min.X+1+rw.StringWidth(string(self.filter)), prompt.Min.Y)
+		}
+		p.PopClip()
+		inner.Max.Y--
+	}
+
+	v := self.view()
+	cursor := v.pos(self.SelectedRow)
+	point := inner.Min
 
 	// adjusts view into widget
-	if self.SelectedRow >= self.Inner.Dy()+self.topRow {
-		self.topRow = self.SelectedRow - self.Inner.Dy() + 1
-	} else if self.SelectedRow < self.topRow {
-		self.topRow = self.SelectedRow
+	if cursor >= inner.Dy()+self.topRow {
+		self.topRow = cursor - inner.Dy() + 1
+	} else if cursor < self.topRow {
+		self.topRow = cursor
 	}
+	// Keep the view full when filtering hides rows below it.
+	self.topRow = MinInt(self.topRow, MaxInt(v.len()-inner.Dy(), 0))
 
 	// draw rows
-	for row := self.topRow; row < len(self.Rows) && point.Y < self.Inner.Max.Y; row++ {
-		cells := ParseStyles(self.Rows[row], self.TextStyle)
-		if self.WrapText {
-			cells = WrapCells(cells, uint(self.Inner.Dx()))
+	for pos := self.topRow; pos < v.len() && point.Y < inner.Max.Y; pos++ {
+		row := v.row(pos)
+		cells := ParseStyles(self.row(row), self.TextStyle)
+		match := self.MatchStyle
+		highlight := row == self.SelectedRow || self.MultiSelect && self.marked[row]
+		if highlight {
+			// Only the modifiers of matches show on highlighted rows.
+			match = Style{Fg: ColorClear, Bg: ColorClear, Modifier: match.Modifier}
 		}
-		for j := 0; j < len(cells) && point.Y < self.Inner.Max.Y; j++ {
-			style := cells[j].Style
+		for j := range cells {
 			if row == self.SelectedRow {
-				style = self.SelectedRowStyle
+				cells[j].Style = self.SelectedRowStyle
+			} else if highlight {
+				cells[j].Style = self.MarkedRowStyle
 			}
+		}
+		if from := self.match(cells); len(self.filter) > 0 && from >= 0 {
+			for j := from; j < from+len(self.filter); j++ {
+				cells[j].Style = mergeStyle(cells[j].Style, match)
+			}
+		}
+		if self.WrapText {
+			cells = WrapCells(cells, uint(inner.Dx()))
+		}
+		for j := 0; j < len(cells) && point.Y < inner.Max.Y; j++ {
+			style := cells[j].Style
 			if cells[j].Rune == '\n' {
-				point = image.Pt(self.Inner.Min.X, point.Y+1)
+				point = image.Pt(inner.Min.X, point.Y+1)
 			} else {
-				if point.X+1 == self.Inner.Max.X+1 && len(cells) > self.Inner.Dx() {
-					buf.SetCell(NewCell(ELLIPSES, style), point.Add(image.Pt(-1, 0)))
+				if point.X+1 == inner.Max.X+1 && len(cells) > inner.Dx() {
+					p.SetCell(NewCell(ELLIPSES, style), point.Add(image.Pt(-1, 0)))
 					break
 				} else {
-					buf.SetCell(NewCell(cells[j].Rune, style), point)
+					p.SetCell(NewCell(cells[j].Rune, style), point)
 					point = point.Add(image.Pt(rw.RuneWidth(cells[j].Rune), 0))
 				}
 			}
 		}
-		point = image.Pt(self.Inner.Min.X, point.Y+1)
+		point = image.Pt(inner.Min.X, point.Y+1)
 	}
 
 	// draw UP_ARROW if needed
 	if self.topRow > 0 {
-		buf.SetCell(
+		p.SetCell(
 			NewCell(UP_ARROW, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y),
+			image.Pt(inner.Max.X-1, inner.Min.Y),
 		)
 	}
 
 	// draw DOWN_ARROW if needed
-	if len(self.Rows) > int(self.topRow)+self.Inner.Dy() {
-		buf.SetCell(
+	if v.len() > int(self.topRow)+inner.Dy() {
+		p.SetCell(
 			NewCell(DOWN_ARROW, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Max.X-1, self.Inner.Max.Y-1),
+			image.Pt(inner.Max.X-1, inner.Max.Y-1),
 		)
 	}
 }
 
+// mergeStyle returns s with the colors and modifiers set in delta.
+func mergeStyle(s, delta Style) Style {
+	if delta.Fg != ColorClear {
+		s.Fg = delta.Fg
+	}
+	if delta.Bg != ColorClear {
+		s.Bg = delta.Bg
+	}
+	s.Modifier |= delta.Modifier
+	return s
+}
+
+// SizeHint returns the size showing every row in full. Only the first rows
+// of a DataSource are measured.
+func (self *List) SizeHint() image.Point {
+	var width int
+	for _, row := range self.rowsIn(0, self.hintRows()) {
+		width = MaxInt(width, rw.StringWidth(CellsToString(ParseStyles(row, self.TextStyle))))
+	}
+	return image.Pt(width, self.rowCount()).Add(self.FrameSize())
+}
+
+// hintRows returns the number of rows measured for the size hints.
+func (self *List) hintRows() int {
+	if self.source != nil {
+		return sourceHintRows
+	}
+	return len(self.Rows)
+}
+
+// MinSizeHint returns the size showing a single cell.
+func (self *List) MinSizeHint() image.Point {
+	return image.Pt(1, 1).Add(self.FrameSize())
+}
+
+// Keybindings returns the keys handled by the list.
+func (self *List) Keybindings() []KeyHelp {
+	keys := []KeyHelp{
+		{Keys: []string{KeyArrowUp, KeyArrowDown}, Description: "Move to the previous or next row"},
+		{Keys: []string{KeyPgup, KeyPgdn}, Description: "Move up or down one page"},
+		{Keys: []string{KeyHome, KeyEnd}, Description: "Move to the first or last row"},
+		{Keys: []string{KeyEnter}, Description: "Activate the row"},
+		{Keys: []string{"/"}, Description: "Filter the rows"},
+		{Keys: []string{KeyEsc}, Description: "Clear the filter"},
+	}
+	if self.MultiSelect {
+		keys = append(keys,
+			KeyHelp{Keys: []string{KeySpace}, Description: "Select or unselect the row"},
+			KeyHelp{Keys: []string{KeyShiftArrowUp, KeyShiftArrowDown, KeyShiftHome, KeyShiftEnd}, Description: "Select the rows moved over"},
+		)
+	}
+	return keys
+}
+
+// DoEvent moves the cursor with the arrow keys, PgUp/PgDn and Home/End when
+// the list is focused, edits the filter after "/", and selects the rows in
+// MultiSelect mode. Clicking a row moves the cursor to it, and the mouse
+// wheel scrolls.
+func (self *List) DoEvent(e Event) bool {
+	switch e.Type {
+	case KeyboardEvent:
+		if !self.IsFocused() {
+			return false
+		}
+		if self.filtering && self.doFilterKey(e) {
+			break
+		}
+		if !self.doKeyEvent(e) {
+			return false
+		}
+	case MouseEvent:
+		if !self.doMouseEvent(e) {
+			return false
+		}
+	default:
+		return false
+	}
+	self.Refresh()
+	return true
+}
+
+// doFilterKey edits the filter while it is being typed.
+func (self *List) doFilterKey(e Event) bool {
+	switch {
+	case e.ID == KeyEnter:
+		self.filtering = false
+	case e.ID == KeyEsc:
+		self.filtering = false
+		self.SetFilter("")
+	case e.ID == KeyBackspace || e.ID == KeyBackspace2:
+		if len(self.filter) == 0 {
+			self.filtering = false
+			return true
+		}
+		self.SetFilter(string(self.filter[:len(self.filter)-1]))
+	case e.ID == KeySpace:
+		self.SetFilter(string(self.filter) + " ")
+	case len(e.ID) > 0 && e.ID[0] != '<':
+		self.SetFilter(string(self.filter) + e.ID)
+	default:
+		return false
+	}
+	return true
+}
+
+func (self *List) doKeyEvent(e Event) bool {
+	if self.rowCount() == 0 && e.ID != "/" {
+		return false
+	}
+	shift := false
+	switch e.ID {
+	case KeyShiftArrowUp, KeyShiftArrowDown, KeyShiftHome, KeyShiftEnd:
+		if !self.MultiSelect {
+			return false
+		}
+		shift = true
+	}
+	switch e.ID {
+	case KeyArrowUp, KeyShiftArrowUp:
+		self.scrollBy(-1, shift)
+	case KeyArrowDown, KeyShiftArrowDown:
+		self.scrollBy(1, shift)
+	case KeyPgup:
+		self.ScrollPageUp()
+	case KeyPgdn:
+		self.ScrollPageDown()
+	case KeyHome, KeyShiftHome:
+		self.scrollBy(-self.rowCount(), shift)
+	case KeyEnd, KeyShiftEnd:
+		self.scrollBy(self.rowCount(), shift)
+	case KeySpace:
+		if !self.MultiSelect {
+			return false
+		}
+		self.toggle(self.SelectedRow)
+	case KeyEnter:
+		self.activate()
+	case "/":
+		self.filtering = true
+	case KeyEsc:
+		if len(self.filter) == 0 {
+			return false
+		}
+		self.SetFilter("")
+	default:
+		return false
+	}
+	return true
+}
+
+// doMouseEvent moves the cursor to the clicked row, and activates it on a
+// double-click.
+func (self *List) doMouseEvent(e Event) bool {
+	if n := wheelDelta(self, e); n != 0 {
+		self.ScrollAmount(n)
+		return true
+	}
+	m := e.Payload.(Mouse)
+	inner := self.GetInnerRealPos()
+	if e.ID != "<MouseLeft>" || m.Drag || !image.Pt(m.X, m.Y).In(inner) || self.WrapText {
+		return false
+	}
+	v := self.view()
+	pos := self.topRow + m.Y - inner.Min.Y
+	if pos >= v.len() || self.showsFilter() && m.Y == inner.Max.Y-1 {
+		return false
+	}
+	row := v.row(pos)
+	now := time.Now()
+	double := row == self.lastClickRow && now.Sub(self.lastClick) < doubleClickTime
+	self.lastClick, self.lastClickRow = now, row
+	self.moveCursor(row, false)
+	if double {
+		self.lastClickRow = -1
+		self.activate()
+	}
+	return true
+}
+
+// moveCursor moves the cursor to row. In MultiSelect mode, extend selects
+// the rows from the anchor to row, and otherwise row becomes the anchor.
+func (self *List) moveCursor(row int, extend bool) {
+	changed := false
+	if self.MultiSelect && extend {
+		v := self.view()
+		from, to := v.pos(self.anchor), v.pos(row)
+		if from > to {
+			from, to = to, from
+		}
+		for pos := from; pos <= to && pos < v.len(); pos++ {
+			if r := v.row(pos); !self.marked[r] {
+				self.marked[r] = true
+				changed = true
+			}
+		}
+	} else {
+		self.anchor = row
+	}
+	if row != self.SelectedRow {
+		self.SelectedRow = row
+		changed = changed || !self.MultiSelect
+	}
+	if changed && self.onSelectionChanged != nil {
+		self.onSelectionChanged(self)
+	}
+}
+
+// toggle selects the row if it isn't selected, and unselects it otherwise.
+func (self *List) toggle(row int) {
+	self.SetSelected(row, !self.marked[row])
+	self.anchor = row
+	if self.onSelectionChanged != nil {
+		self.onSelectionChanged(self)
+	}
+}
+
+func (self *List) activate() {
+	if self.onActivated != nil && self.SelectedRow >= 0 && self.SelectedRow < self.rowCount() {
+		self.onActivated(self)
+	}
+}
+
+// scrollBy moves the cursor by amount shown rows, up if amount < 0.
+func (self *List) scrollBy(amount int, extend bool) {
+	v := self.view()
+	if v.len() == 0 {
+		return
+	}
+	pos := MaxInt(0, MinInt(v.pos(self.SelectedRow)+amount, v.len()-1))
+	self.moveCursor(v.row(pos), extend)
+}
+
 // ScrollAmount scrolls by amount given. If amount is < 0, then scroll up.
 // There is no need to set self.topRow, as this will be set automatically when drawn,
 // since if the selected item is off screen then the topRow variable will change accordingly.
 func (self *List) ScrollAmount(amount int) {
-	if len(self.Rows)-int(self.SelectedRow) <= amount {
-		self.SelectedRow = len(self.Rows) - 1
-	} else if int(self.SelectedRow)+amount < 0 {
-		self.SelectedRow = 0
-	} else {
-		self.SelectedRow += amount
-	}
+	self.scrollBy(amount, false)
 }
 
 func (self *List) ScrollUp() {
@@ -108,29 +703,29 @@ func (self *List) ScrollDown() {
 
 func (self *List) ScrollPageUp() {
 	// If an item is selected below top row, then go to the top row.
-	if self.SelectedRow > self.topRow {
-		self.SelectedRow = self.topRow
+	if cursor := self.view().pos(self.SelectedRow); cursor > self.topRow {
+		self.ScrollAmount(self.topRow - cursor)
 	} else {
-		self.ScrollAmount(-self.Inner.Dy())
+		self.ScrollAmount(-self.pageRows())
 	}
 }
 
 func (self *List) ScrollPageDown() {
-	self.ScrollAmount(self.Inner.Dy())
+	self.ScrollAmount(self.pageRows())
 }
 
 func (self *List) ScrollHalfPageUp() {
-	self.ScrollAmount(-int(FloorFloat64(float64(self.Inner.Dy()) / 2)))
+	self.ScrollAmount(-int(FloorFloat64(float64(self.pageRows()) / 2)))
 }
 
 func (self *List) ScrollHalfPageDown() {
-	self.ScrollAmount(int(FloorFloat64(float64(self.Inner.Dy()) / 2)))
+	self.ScrollAmount(int(FloorFloat64(float64(self.pageRows()) / 2)))
 }
 
 func (self *List) ScrollTop() {
-	self.SelectedRow = 0
+	self.ScrollAmount(-self.rowCount())
 }
 
 func (self *List) ScrollBottom() {
-	self.SelectedRow = len(self.Rows) - 1
+	self.ScrollAmount(self.rowCount())
 }
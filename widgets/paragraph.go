@@ -7,7 +7,9 @@ package widgets
 import (
 	"image"
 
-	. "github.com/gizak/termui/v3"
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/thzll/termuix"
 )
 
 type Paragraph struct {
@@ -15,6 +17,8 @@ type Paragraph struct {
 	Text      string
 	TextStyle Style
 	WrapText  bool
+
+	topRow int
 }
 
 func NewParagraph() *Paragraph {
@@ -25,24 +29,117 @@ func NewParagraph() *Paragraph {
 	}
 }
 
-func (self *Paragraph) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// SetText sets the text of the paragraph.
+func (self *Paragraph) SetText(text string) {
+	self.Text = text
+	self.Invalidate()
+	self.Refresh()
+}
 
-	cells := ParseStyles(self.Text, self.TextStyle)
-	if self.WrapText {
-		cells = WrapCells(cells, uint(self.Inner.Dx()))
+func (self *Paragraph) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
 	}
+	inner := self.GetInnerRealPos()
 
-	rows := SplitCells(cells, '\n')
+	rows := self.rows(inner.Dx())
+	self.topRow = MaxInt(0, MinInt(self.topRow, len(rows)-inner.Dy()))
 
-	for y, row := range rows {
-		if y+self.Inner.Min.Y >= self.Inner.Max.Y {
+	for y, row := range rows[self.topRow:] {
+		if y+inner.Min.Y >= inner.Max.Y {
 			break
 		}
-		row = TrimCells(row, self.Inner.Dx())
+		row = TrimCells(row, inner.Dx())
 		for _, cx := range BuildCellWithXArray(row) {
 			x, cell := cx.X, cx.Cell
-			buf.SetCell(cell, image.Pt(x, y).Add(self.Inner.Min))
+			p.SetCell(cell, image.Pt(x, y).Add(inner.Min))
+		}
+	}
+}
+
+// rows returns the lines of text, wrapped at width if WrapText is set.
+func (self *Paragraph) rows(width int) [][]Cell {
+	cells := ParseStyles(self.Text, self.TextStyle)
+	if self.WrapText && width > 0 {
+		cells = WrapCells(cells, uint(width))
+	}
+	return SplitCells(cells, '\n')
+}
+
+// SizeHint returns the size showing the text without wrapping.
+func (self *Paragraph) SizeHint() image.Point {
+	rows := self.rows(0)
+	var width int
+	for _, row := range rows {
+		width = MaxInt(width, rw.StringWidth(CellsToString(row)))
+	}
+	return image.Pt(width, len(rows)).Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing a single cell.
+func (self *Paragraph) MinSizeHint() image.Point {
+	return image.Pt(1, 1).Add(self.FrameSize())
+}
+
+// ScrollAmount scrolls the text by amount lines. If amount is < 0, then
+// scroll up.
+func (self *Paragraph) ScrollAmount(amount int) {
+	self.topRow = MaxInt(0, MinInt(self.topRow+amount, self.maxTopRow()))
+}
+
+func (self *Paragraph) ScrollTop() {
+	self.topRow = 0
+}
+
+func (self *Paragraph) ScrollBottom() {
+	self.topRow = self.maxTopRow()
+}
+
+// maxTopRow returns the first line shown when the text is scrolled to the
+// bottom.
+func (self *Paragraph) maxTopRow() int {
+	inner := self.GetInner()
+	return MaxInt(len(self.rows(inner.Dx()))-inner.Dy(), 0)
+}
+
+// DoEvent scrolls the text with the arrow keys, PgUp/PgDn and Home/End when
+// the paragraph is focused, and with the mouse wheel.
+func (self *Paragraph) DoEvent(e Event) bool {
+	switch e.Type {
+	case KeyboardEvent:
+		if !self.IsFocused() {
+			return false
+		}
+		page := self.GetInner().Dy()
+		switch e.ID {
+		case KeyArrowUp:
+			self.ScrollAmount(-1)
+		case KeyArrowDown:
+			self.ScrollAmount(1)
+		case KeyPgup:
+			self.ScrollAmount(-page)
+		case KeyPgdn:
+			self.ScrollAmount(page)
+		case KeyHome:
+			self.ScrollTop()
+		case KeyEnd:
+			self.ScrollBottom()
+		default:
+			return false
+		}
+	case MouseEvent:
+		n := wheelDelta(self, e)
+		if n == 0 {
+			return false
 		}
+		self.ScrollAmount(n)
+	default:
+		return false
 	}
+	self.Refresh()
+	return true
 }
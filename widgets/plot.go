@@ -8,7 +8,8 @@ import (
 	"fmt"
 	"image"
 
-	. "github.com/gizak/termui/v3"
+	. "github.com/thzll/termuix"
+	"github.com/thzll/termuix/drawille"
 )
 
 // Plot has two modes: line(default) and scatter.
@@ -76,9 +77,8 @@ func NewPlot() *Plot {
 	}
 }
 
-func (self *Plot) renderBraille(buf *Buffer, drawArea image.Rectangle, maxVal float64) {
-	canvas := NewCanvas()
-	canvas.Rectangle = drawArea
+func (self *Plot) renderBraille(p *Painter, drawArea image.Rectangle, maxVal float64) {
+	canvas := drawille.NewCanvas()
 
 	switch self.PlotType {
 	case ScatterPlot:
@@ -90,7 +90,7 @@ func (self *Plot) renderBraille(buf *Buffer, drawArea image.Rectangle, maxVal fl
 						(drawArea.Min.X+(j*self.HorizontalScale))*2,
 						(drawArea.Max.Y-height-1)*4,
 					),
-					SelectColor(self.LineColors, i),
+					drawille.Color(SelectColor(self.LineColors, i)),
 				)
 			}
 		}
@@ -108,17 +108,21 @@ func (self *Plot) renderBraille(buf *Buffer, drawArea image.Rectangle, maxVal fl
 						(drawArea.Min.X+((j+1)*self.HorizontalScale))*2,
 						(drawArea.Max.Y-height-1)*4,
 					),
-					SelectColor(self.LineColors, i),
+					drawille.Color(SelectColor(self.LineColors, i)),
 				)
 				previousHeight = height
 			}
 		}
 	}
 
-	canvas.Draw(buf)
+	for point, cell := range canvas.GetCells() {
+		if point.In(drawArea) {
+			p.SetCell(NewCell(cell.Rune, NewStyle(Color(cell.Color))), point)
+		}
+	}
 }
 
-func (self *Plot) renderDot(buf *Buffer, drawArea image.Rectangle, maxVal float64) {
+func (self *Plot) renderDot(p *Painter, drawArea image.Rectangle, maxVal float64) {
 	switch self.PlotType {
 	case ScatterPlot:
 		for i, line := range self.Data {
@@ -126,7 +130,7 @@ func (self *Plot) renderDot(buf *Buffer, drawArea image.Rectangle, maxVal float6
 				height := int((val / maxVal) * float64(drawArea.Dy()-1))
 				point := image.Pt(drawArea.Min.X+(j*self.HorizontalScale), drawArea.Max.Y-1-height)
 				if point.In(drawArea) {
-					buf.SetCell(
+					p.SetCell(
 						NewCell(self.DotMarkerRune, NewStyle(SelectColor(self.LineColors, i))),
 						point,
 					)
@@ -138,7 +142,7 @@ func (self *Plot) renderDot(buf *Buffer, drawArea image.Rectangle, maxVal float6
 			for j := 0; j < len(line) && j*self.HorizontalScale < drawArea.Dx(); j++ {
 				val := line[j]
 				height := int((val / maxVal) * float64(drawArea.Dy()-1))
-				buf.SetCell(
+				p.SetCell(
 					NewCell(self.DotMarkerRune, NewStyle(SelectColor(self.LineColors, i))),
 					image.Pt(drawArea.Min.X+(j*self.HorizontalScale), drawArea.Max.Y-1-height),
 				)
@@ -147,59 +151,94 @@ func (self *Plot) renderDot(buf *Buffer, drawArea image.Rectangle, maxVal float6
 	}
 }
 
-func (self *Plot) plotAxes(buf *Buffer, maxVal float64) {
+func (self *Plot) plotAxes(p *Painter, inner image.Rectangle, maxVal float64) {
 	// draw origin cell
-	buf.SetCell(
+	p.SetCell(
 		NewCell(BOTTOM_LEFT, NewStyle(ColorWhite)),
-		image.Pt(self.Inner.Min.X+yAxisLabelsWidth, self.Inner.Max.Y-xAxisLabelsHeight-1),
+		image.Pt(inner.Min.X+yAxisLabelsWidth, inner.Max.Y-xAxisLabelsHeight-1),
 	)
 	// draw x axis line
-	for i := yAxisLabelsWidth + 1; i < self.Inner.Dx(); i++ {
-		buf.SetCell(
+	for i := yAxisLabelsWidth + 1; i < inner.Dx(); i++ {
+		p.SetCell(
 			NewCell(HORIZONTAL_DASH, NewStyle(ColorWhite)),
-			image.Pt(i+self.Inner.Min.X, self.Inner.Max.Y-xAxisLabelsHeight-1),
+			image.Pt(i+inner.Min.X, inner.Max.Y-xAxisLabelsHeight-1),
 		)
 	}
 	// draw y axis line
-	for i := 0; i < self.Inner.Dy()-xAxisLabelsHeight-1; i++ {
-		buf.SetCell(
+	for i := 0; i < inner.Dy()-xAxisLabelsHeight-1; i++ {
+		p.SetCell(
 			NewCell(VERTICAL_DASH, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Min.X+yAxisLabelsWidth, i+self.Inner.Min.Y),
+			image.Pt(inner.Min.X+yAxisLabelsWidth, i+inner.Min.Y),
 		)
 	}
 	// draw x axis labels
 	// draw 0
-	buf.SetString(
+	p.SetString(
 		"0",
 		NewStyle(ColorWhite),
-		image.Pt(self.Inner.Min.X+yAxisLabelsWidth, self.Inner.Max.Y-1),
+		image.Pt(inner.Min.X+yAxisLabelsWidth, inner.Max.Y-1),
 	)
 	// draw rest
-	for x := self.Inner.Min.X + yAxisLabelsWidth + (xAxisLabelsGap)*self.HorizontalScale + 1; x < self.Inner.Max.X-1; {
+	for x := inner.Min.X + yAxisLabelsWidth + (xAxisLabelsGap)*self.HorizontalScale + 1; x < inner.Max.X-1; {
 		label := fmt.Sprintf(
 			"%d",
-			(x-(self.Inner.Min.X+yAxisLabelsWidth)-1)/(self.HorizontalScale)+1,
+			(x-(inner.Min.X+yAxisLabelsWidth)-1)/(self.HorizontalScale)+1,
 		)
-		buf.SetString(
+		p.SetString(
 			label,
 			NewStyle(ColorWhite),
-			image.Pt(x, self.Inner.Max.Y-1),
+			image.Pt(x, inner.Max.Y-1),
 		)
 		x += (len(label) + xAxisLabelsGap) * self.HorizontalScale
 	}
 	// draw y axis labels
-	verticalScale := maxVal / float64(self.Inner.Dy()-xAxisLabelsHeight-1)
-	for i := 0; i*(yAxisLabelsGap+1) < self.Inner.Dy()-1; i++ {
-		buf.SetString(
+	verticalScale := maxVal / float64(inner.Dy()-xAxisLabelsHeight-1)
+	for i := 0; i*(yAxisLabelsGap+1) < inner.Dy()-1; i++ {
+		p.SetString(
 			fmt.Sprintf("%.2f", float64(i)*verticalScale*(yAxisLabelsGap+1)),
 			NewStyle(ColorWhite),
-			image.Pt(self.Inner.Min.X, self.Inner.Max.Y-(i*(yAxisLabelsGap+1))-2),
+			image.Pt(inner.Min.X, inner.Max.Y-(i*(yAxisLabelsGap+1))-2),
 		)
 	}
 }
 
-func (self *Plot) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// plotHeight is the height of the drawing area in the size hint of a plot.
+const plotHeight = 10
+
+// SizeHint returns the size showing every data point.
+func (self *Plot) SizeHint() image.Point {
+	var n int
+	for _, line := range self.Data {
+		n = MaxInt(n, len(line))
+	}
+	size := image.Pt(n*self.HorizontalScale, plotHeight)
+	if self.Marker == MarkerBraille {
+		size.X = (size.X + 1) / 2
+	}
+	if self.ShowAxes {
+		size = size.Add(image.Pt(yAxisLabelsWidth+1, xAxisLabelsHeight+1))
+	}
+	return size.Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing the axes and a single cell of data.
+func (self *Plot) MinSizeHint() image.Point {
+	size := image.Pt(1, 1)
+	if self.ShowAxes {
+		size = size.Add(image.Pt(yAxisLabelsWidth+1, xAxisLabelsHeight+1))
+	}
+	return size.Add(self.FrameSize())
+}
+
+func (self *Plot) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
 
 	maxVal := self.MaxVal
 	if maxVal == 0 {
@@ -207,21 +246,21 @@ func (self *Plot) Draw(buf *Buffer) {
 	}
 
 	if self.ShowAxes {
-		self.plotAxes(buf, maxVal)
+		self.plotAxes(p, inner, maxVal)
 	}
 
-	drawArea := self.Inner
+	drawArea := inner
 	if self.ShowAxes {
 		drawArea = image.Rect(
-			self.Inner.Min.X+yAxisLabelsWidth+1, self.Inner.Min.Y,
-			self.Inner.Max.X, self.Inner.Max.Y-xAxisLabelsHeight-1,
+			inner.Min.X+yAxisLabelsWidth+1, inner.Min.Y,
+			inner.Max.X, inner.Max.Y-xAxisLabelsHeight-1,
 		)
 	}
 
 	switch self.Marker {
 	case MarkerBraille:
-		self.renderBraille(buf, drawArea, maxVal)
+		self.renderBraille(p, drawArea, maxVal)
 	case MarkerDot:
-		self.renderDot(buf, drawArea, maxVal)
+		self.renderDot(p, drawArea, maxVal)
 	}
 }
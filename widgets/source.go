@@ -0,0 +1,182 @@
+// Copyright 2021. thzll <tanghuizll@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"sync"
+
+	. "github.com/thzll/termuix"
+)
+
+// sourceHintRows is the number of rows of a DataSource measured for the size
+// hints of a Table.
+const sourceHintRows = 100
+
+// DataSource provides the rows of a List or a Table on demand, so that only
+// the rows being drawn are asked for. A List shows the first cell of each
+// row.
+type DataSource interface {
+	// Len returns the number of rows.
+	Len() int
+	// Rows returns the rows from index from up to, but not including, to.
+	Rows(from, to int) [][]string
+}
+
+// Change is the kind of change made to the rows of a DataSource.
+type Change int
+
+const (
+	// RowsAppended means that rows were added at the end, and the other
+	// rows are unchanged.
+	RowsAppended Change = iota
+	// RowsReset means that any of the rows may have changed.
+	RowsReset
+)
+
+// ChangeNotifier is implemented by DataSources whose rows change. A List or
+// Table showing such a source is updated and drawn again on the UI goroutine
+// after each change, so the rows may be changed from any goroutine.
+type ChangeNotifier interface {
+	// Subscribe has fn called after each change, until cancel is called.
+	// fn may be called from any goroutine.
+	Subscribe(fn func(c Change)) (cancel func())
+}
+
+// StreamSource is a DataSource that rows can be appended to while it is
+// shown, like the lines of a log read by another goroutine. It is safe for
+// concurrent use.
+type StreamSource struct {
+	mu   sync.RWMutex
+	rows [][]string
+	subs map[int]func(Change)
+	next int
+}
+
+var (
+	_ DataSource     = &StreamSource{}
+	_ ChangeNotifier = &StreamSource{}
+)
+
+// NewStreamSource returns a new StreamSource holding rows.
+func NewStreamSource(rows ...[]string) *StreamSource {
+	return &StreamSource{
+		rows: rows,
+		subs: make(map[int]func(Change)),
+	}
+}
+
+// Len returns the number of rows.
+func (s *StreamSource) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.rows)
+}
+
+// Rows returns the rows from index from up to, but not including, to,
+// limited to the rows there are.
+func (s *StreamSource) Rows(from, to int) [][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	from = MaxInt(from, 0)
+	to = MinInt(to, len(s.rows))
+	if from >= to {
+		return nil
+	}
+	return s.rows[from:to:to]
+}
+
+// Append adds rows at the end.
+func (s *StreamSource) Append(rows ...[]string) {
+	s.mu.Lock()
+	s.rows = append(s.rows, rows...)
+	s.mu.Unlock()
+	s.notify(RowsAppended)
+}
+
+// AppendLine adds a row of a single cell at the end, as shown by a List.
+func (s *StreamSource) AppendLine(line string) {
+	s.Append([]string{line})
+}
+
+// Set replaces all rows.
+func (s *StreamSource) Set(rows ...[]string) {
+	s.mu.Lock()
+	s.rows = rows
+	s.mu.Unlock()
+	s.notify(RowsReset)
+}
+
+// Subscribe has fn called after each change, until cancel is called.
+func (s *StreamSource) Subscribe(fn func(c Change)) (cancel func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.next
+	s.next++
+	s.subs[id] = fn
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subs, id)
+	}
+}
+
+// notify calls the subscribers without holding the lock, so that they can
+// read the rows.
+func (s *StreamSource) notify(c Change) {
+	s.mu.RLock()
+	subs := make([]func(Change), 0, len(s.subs))
+	for _, fn := range s.subs {
+		subs = append(subs, fn)
+	}
+	s.mu.RUnlock()
+	for _, fn := range subs {
+		fn(c)
+	}
+}
+
+// subscribe has fn called through post, i.e. on the UI goroutine, after
+// changes of src, if it notifies of them, and returns the function cancelling
+// it. Changes made before fn gets to run are merged into one call, so that
+// appending many rows queues a single update of the widget.
+func subscribe(src DataSource, post func(func()), fn func(c Change)) func() {
+	n, ok := src.(ChangeNotifier)
+	if !ok {
+		return func() {}
+	}
+	var (
+		mu        sync.Mutex
+		pending   bool
+		merged    Change
+		cancelled bool
+	)
+	cancel := n.Subscribe(func(c Change) {
+		mu.Lock()
+		if pending {
+			if c == RowsReset {
+				merged = RowsReset
+			}
+			mu.Unlock()
+			return
+		}
+		pending, merged = true, c
+		mu.Unlock()
+
+		post(func() {
+			mu.Lock()
+			c, stale := merged, cancelled
+			pending = false
+			mu.Unlock()
+			if !stale {
+				fn(c)
+			}
+		})
+	})
+	return func() {
+		cancel()
+		mu.Lock()
+		cancelled = true
+		mu.Unlock()
+	}
+}
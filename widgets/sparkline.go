@@ -7,7 +7,9 @@ package widgets
 import (
 	"image"
 
-	. "github.com/gizak/termui/v3"
+	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/thzll/termuix"
 )
 
 // Sparkline is like: ▅▆▂▂▅▇▂▂▃▆▆▆▅▃. The data points should be non-negative integers.
@@ -41,17 +43,63 @@ func NewSparklineGroup(sls ...*Sparkline) *SparklineGroup {
 	}
 }
 
-func (self *SparklineGroup) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// sparklineHintHeight is the height of a sparkline in the size hint when its
+// MaxHeight isn't set.
+const sparklineHintHeight = 3
+
+// SizeHint returns the size showing every data point of every sparkline.
+func (self *SparklineGroup) SizeHint() image.Point {
+	var size image.Point
+	for _, sl := range self.Sparklines {
+		size.X = MaxInt(size.X, len(sl.Data))
+		if sl.MaxHeight > 0 {
+			size.Y += sl.MaxHeight
+		} else {
+			size.Y += sparklineHintHeight
+		}
+		if sl.Title != "" {
+			size.X = MaxInt(size.X, rw.StringWidth(sl.Title))
+			size.Y++
+		}
+	}
+	return size.Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing one line of each sparkline and its
+// title.
+func (self *SparklineGroup) MinSizeHint() image.Point {
+	var size image.Point
+	for _, sl := range self.Sparklines {
+		size.Y++
+		if sl.Title != "" {
+			size.Y++
+		}
+	}
+	size.X = MinInt(len(self.Sparklines), 1)
+	return size.Add(self.FrameSize())
+}
+
+func (self *SparklineGroup) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
+	if len(self.Sparklines) == 0 {
+		return
+	}
 
-	sparklineHeight := self.Inner.Dy() / len(self.Sparklines)
+	sparklineHeight := inner.Dy() / len(self.Sparklines)
 
 	for i, sl := range self.Sparklines {
 		heightOffset := (sparklineHeight * (i + 1))
 		barHeight := sparklineHeight
 		if i == len(self.Sparklines)-1 {
-			heightOffset = self.Inner.Dy()
-			barHeight = self.Inner.Dy() - (sparklineHeight * i)
+			heightOffset = inner.Dy()
+			barHeight = inner.Dy() - (sparklineHeight * i)
 		}
 		if sl.Title != "" {
 			barHeight--
@@ -63,31 +111,31 @@ func (self *SparklineGroup) Draw(buf *Buffer) {
 		}
 
 		// draw line
-		for j := 0; j < len(sl.Data) && j < self.Inner.Dx(); j++ {
+		for j := 0; j < len(sl.Data) && j < inner.Dx(); j++ {
 			data := sl.Data[j]
 			height := int((data / maxVal) * float64(barHeight))
 			sparkChar := BARS[len(BARS)-1]
 			for k := 0; k < height; k++ {
-				buf.SetCell(
+				p.SetCell(
 					NewCell(sparkChar, NewStyle(sl.LineColor)),
-					image.Pt(j+self.Inner.Min.X, self.Inner.Min.Y-1+heightOffset-k),
+					image.Pt(j+inner.Min.X, inner.Min.Y-1+heightOffset-k),
 				)
 			}
 			if height == 0 {
 				sparkChar = BARS[1]
-				buf.SetCell(
+				p.SetCell(
 					NewCell(sparkChar, NewStyle(sl.LineColor)),
-					image.Pt(j+self.Inner.Min.X, self.Inner.Min.Y-1+heightOffset),
+					image.Pt(j+inner.Min.X, inner.Min.Y-1+heightOffset),
 				)
 			}
 		}
 
 		if sl.Title != "" {
 			// draw title
-			buf.SetString(
-				TrimString(sl.Title, self.Inner.Dx()),
+			p.SetString(
+				TrimString(sl.Title, inner.Dx()),
 				sl.TitleStyle,
-				image.Pt(self.Inner.Min.X, self.Inner.Min.Y-1+heightOffset-barHeight),
+				image.Pt(inner.Min.X, inner.Min.Y-1+heightOffset-barHeight),
 			)
 		}
 	}
@@ -10,7 +10,7 @@ import (
 
 	rw "github.com/mattn/go-runewidth"
 
-	. "github.com/gizak/termui/v3"
+	. "github.com/thzll/termuix"
 )
 
 type StackedBarChart struct {
@@ -38,8 +38,26 @@ func NewStackedBarChart() *StackedBarChart {
 	}
 }
 
-func (self *StackedBarChart) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// SizeHint returns the size showing every bar.
+func (self *StackedBarChart) SizeHint() image.Point {
+	width := barsWidth(len(self.Data), self.BarWidth, self.BarGap)
+	return image.Pt(width, barChartHeight+1).Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing a single bar with its label.
+func (self *StackedBarChart) MinSizeHint() image.Point {
+	return image.Pt(self.BarWidth, 3).Add(self.FrameSize())
+}
+
+func (self *StackedBarChart) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
 
 	maxVal := self.MaxVal
 	if maxVal == 0 {
@@ -48,31 +66,31 @@ func (self *StackedBarChart) Draw(buf *Buffer) {
 		}
 	}
 
-	barXCoordinate := self.Inner.Min.X
+	barXCoordinate := inner.Min.X
 
 	for i, bar := range self.Data {
 		// draw stacked bars
 		stackedBarYCoordinate := 0
 		for j, data := range bar {
 			// draw each stacked bar
-			height := int((data / maxVal) * float64(self.Inner.Dy()-1))
-			for x := barXCoordinate; x < MinInt(barXCoordinate+self.BarWidth, self.Inner.Max.X); x++ {
-				for y := (self.Inner.Max.Y - 2) - stackedBarYCoordinate; y > (self.Inner.Max.Y-2)-stackedBarYCoordinate-height; y-- {
+			height := int((data / maxVal) * float64(inner.Dy()-1))
+			for x := barXCoordinate; x < MinInt(barXCoordinate+self.BarWidth, inner.Max.X); x++ {
+				for y := (inner.Max.Y - 2) - stackedBarYCoordinate; y > (inner.Max.Y-2)-stackedBarYCoordinate-height; y-- {
 					c := NewCell(' ', NewStyle(ColorClear, SelectColor(self.BarColors, j)))
-					buf.SetCell(c, image.Pt(x, y))
+					p.SetCell(c, image.Pt(x, y))
 				}
 			}
 
 			// draw number
 			numberXCoordinate := barXCoordinate + int((float64(self.BarWidth) / 2)) - 1
-			buf.SetString(
+			p.SetString(
 				self.NumFormatter(data),
 				NewStyle(
 					SelectStyle(self.NumStyles, j+1).Fg,
 					SelectColor(self.BarColors, j),
 					SelectStyle(self.NumStyles, j+1).Modifier,
 				),
-				image.Pt(numberXCoordinate, (self.Inner.Max.Y-2)-stackedBarYCoordinate),
+				image.Pt(numberXCoordinate, (inner.Max.Y-2)-stackedBarYCoordinate),
 			)
 
 			stackedBarYCoordinate += height
@@ -84,10 +102,10 @@ func (self *StackedBarChart) Draw(buf *Buffer) {
 				int((float64(self.BarWidth)/2))-int((float64(rw.StringWidth(self.Labels[i]))/2)),
 				0,
 			)
-			buf.SetString(
+			p.SetString(
 				TrimString(self.Labels[i], self.BarWidth),
 				SelectStyle(self.LabelStyles, i),
-				image.Pt(labelXCoordinate, self.Inner.Max.Y-1),
+				image.Pt(labelXCoordinate, inner.Max.Y-1),
 			)
 		}
 
@@ -7,10 +7,11 @@ package widgets
 import (
 	"image"
 
-	. "github.com/gizak/termui/v3"
+	. "github.com/thzll/termuix"
 )
 
-/*Table is like:
+/*
+Table is like:
 ┌ Awesome Table ───────────────────────────────────────────────┐
 │  Col0          | Col1 | Col2 | Col3  | Col4  | Col5  | Col6  |
 │──────────────────────────────────────────────────────────────│
@@ -18,6 +19,9 @@ import (
 │──────────────────────────────────────────────────────────────│
 │  Some Item #2  | BBB  | 456  | DDDDD | FFFFF | HHHHH | JJJJJ |
 └──────────────────────────────────────────────────────────────┘
+
+The rows come from Rows, or from a DataSource set with SetSource, of which
+only the rows drawn are fetched.
 */
 type Table struct {
 	Block
@@ -25,10 +29,17 @@ type Table struct {
 	ColumnWidths  []int
 	TextStyle     Style
 	RowSeparator  bool
-	TextAlignment Alignment
+	TextAlignment Align
 	RowStyles     map[int]Style
 	FillRow       bool
 
+	topRow int
+
+	source       DataSource
+	cancelSource func()
+	// knownLen is the number of rows of the source before its last change.
+	knownLen int
+
 	// ColumnResizer is called on each Draw. Can be used for custom column sizing.
 	ColumnResizer func()
 }
@@ -43,26 +54,102 @@ func NewTable() *Table {
 	}
 }
 
-func (self *Table) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// SetSource has the table show the rows of src instead of Rows, fetching only
+// the rows it draws. If src is a ChangeNotifier, the table is drawn again
+// after each change, and a table scrolled to the bottom stays at the bottom
+// as rows are appended. Setting nil shows Rows again.
+func (self *Table) SetSource(src DataSource) {
+	self.Lock()
+	defer self.Unlock()
+	if self.cancelSource != nil {
+		self.cancelSource()
+		self.cancelSource = nil
+	}
+	self.source = src
+	if src != nil {
+		self.knownLen = src.Len()
+		self.cancelSource = subscribe(src, self.Post, self.sourceChanged)
+	}
+	self.Invalidate()
+}
 
-	self.ColumnResizer()
+// SetRows sets the rows shown when there is no DataSource.
+func (self *Table) SetRows(rows [][]string) {
+	self.Rows = rows
+	self.Invalidate()
+	self.Refresh()
+}
 
-	columnWidths := self.ColumnWidths
-	if len(columnWidths) == 0 {
-		columnCount := len(self.Rows[0])
-		columnWidth := self.Inner.Dx() / columnCount
-		for i := 0; i < columnCount; i++ {
-			columnWidths = append(columnWidths, columnWidth)
-		}
+// Source returns the DataSource of the table, or nil if it shows Rows.
+func (self *Table) Source() DataSource {
+	return self.source
+}
+
+// sourceChanged keeps a table scrolled to the bottom at the bottom, and draws
+// it again. It runs on the UI goroutine.
+func (self *Table) sourceChanged(Change) {
+	self.Lock()
+	if self.source == nil {
+		self.Unlock()
+		return
+	}
+	atBottom := self.topRow >= MaxInt(self.knownLen-self.pageRows(), 0)
+	self.knownLen = self.source.Len()
+	if atBottom {
+		self.topRow = self.maxTopRow()
+	}
+	self.Unlock()
+	self.Invalidate()
+	self.Refresh()
+}
+
+// rowCount returns the number of rows.
+func (self *Table) rowCount() int {
+	if self.source != nil {
+		return self.source.Len()
+	}
+	return len(self.Rows)
+}
+
+// rowsIn returns the rows from index from up to, but not including, to.
+func (self *Table) rowsIn(from, to int) [][]string {
+	if self.source != nil {
+		return self.source.Rows(from, to)
+	}
+	from = MaxInt(from, 0)
+	to = MinInt(to, len(self.Rows))
+	if from >= to {
+		return nil
 	}
+	return self.Rows[from:to]
+}
+
+func (self *Table) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
+
+	self.ColumnResizer()
+	self.topRow = MaxInt(0, MinInt(self.topRow, self.maxTopRow()))
+	rows := self.rowsIn(self.topRow, self.topRow+self.pageRows())
+	if len(rows) == 0 {
+		return
+	}
+	last := self.rowCount() - 1
+
+	columnWidths := self.fitColumns(inner.Dx())
 
-	yCoordinate := self.Inner.Min.Y
+	yCoordinate := inner.Min.Y
 
 	// draw rows
-	for i := 0; i < len(self.Rows) && yCoordinate < self.Inner.Max.Y; i++ {
-		row := self.Rows[i]
-		colXCoordinate := self.Inner.Min.X
+	for n := 0; n < len(rows) && yCoordinate < inner.Max.Y; n++ {
+		i, row := self.topRow+n, rows[n]
+		colXCoordinate := inner.Min.X
 
 		rowStyle := self.TextStyle
 		// get the row style if one exists
@@ -72,22 +159,22 @@ func (self *Table) Draw(buf *Buffer) {
 
 		if self.FillRow {
 			blankCell := NewCell(' ', rowStyle)
-			buf.Fill(blankCell, image.Rect(self.Inner.Min.X, yCoordinate, self.Inner.Max.X, yCoordinate+1))
+			p.Fill(blankCell, image.Rect(inner.Min.X, yCoordinate, inner.Max.X, yCoordinate+1))
 		}
 
 		// draw row cells
-		for j := 0; j < len(row); j++ {
+		for j := 0; j < len(row) && j < len(columnWidths); j++ {
 			col := ParseStyles(row[j], rowStyle)
 			// draw row cell
-			if len(col) > columnWidths[j] || self.TextAlignment == AlignLeft {
+			if len(col) > columnWidths[j] || self.TextAlignment == AlignStretch || self.TextAlignment == AlignStart {
 				for _, cx := range BuildCellWithXArray(col) {
 					k, cell := cx.X, cx.Cell
-					if k == columnWidths[j] || colXCoordinate+k == self.Inner.Max.X {
+					if k == columnWidths[j] || colXCoordinate+k == inner.Max.X {
 						cell.Rune = ELLIPSES
-						buf.SetCell(cell, image.Pt(colXCoordinate+k-1, yCoordinate))
+						p.SetCell(cell, image.Pt(colXCoordinate+k-1, yCoordinate))
 						break
 					} else {
-						buf.SetCell(cell, image.Pt(colXCoordinate+k, yCoordinate))
+						p.SetCell(cell, image.Pt(colXCoordinate+k, yCoordinate))
 					}
 				}
 			} else if self.TextAlignment == AlignCenter {
@@ -95,13 +182,13 @@ func (self *Table) Draw(buf *Buffer) {
 				stringXCoordinate := xCoordinateOffset + colXCoordinate
 				for _, cx := range BuildCellWithXArray(col) {
 					k, cell := cx.X, cx.Cell
-					buf.SetCell(cell, image.Pt(stringXCoordinate+k, yCoordinate))
+					p.SetCell(cell, image.Pt(stringXCoordinate+k, yCoordinate))
 				}
-			} else if self.TextAlignment == AlignRight {
-				stringXCoordinate := MinInt(colXCoordinate+columnWidths[j], self.Inner.Max.X) - len(col)
+			} else if self.TextAlignment == AlignEnd {
+				stringXCoordinate := MinInt(colXCoordinate+columnWidths[j], inner.Max.X) - len(col)
 				for _, cx := range BuildCellWithXArray(col) {
 					k, cell := cx.X, cx.Cell
-					buf.SetCell(cell, image.Pt(stringXCoordinate+k, yCoordinate))
+					p.SetCell(cell, image.Pt(stringXCoordinate+k, yCoordinate))
 				}
 			}
 			colXCoordinate += columnWidths[j] + 1
@@ -110,7 +197,7 @@ func (self *Table) Draw(buf *Buffer) {
 		// draw vertical separators
 		separatorStyle := self.Block.BorderStyle
 
-		separatorXCoordinate := self.Inner.Min.X
+		separatorXCoordinate := inner.Min.X
 		verticalCell := NewCell(VERTICAL_LINE, separatorStyle)
 		for i, width := range columnWidths {
 			if self.FillRow && i < len(columnWidths)-1 {
@@ -120,7 +207,7 @@ func (self *Table) Draw(buf *Buffer) {
 			}
 
 			separatorXCoordinate += width
-			buf.SetCell(verticalCell, image.Pt(separatorXCoordinate, yCoordinate))
+			p.SetCell(verticalCell, image.Pt(separatorXCoordinate, yCoordinate))
 			separatorXCoordinate++
 		}
 
@@ -128,9 +215,168 @@ func (self *Table) Draw(buf *Buffer) {
 
 		// draw horizontal separator
 		horizontalCell := NewCell(HORIZONTAL_LINE, separatorStyle)
-		if self.RowSeparator && yCoordinate < self.Inner.Max.Y && i != len(self.Rows)-1 {
-			buf.Fill(horizontalCell, image.Rect(self.Inner.Min.X, yCoordinate, self.Inner.Max.X, yCoordinate+1))
+		if self.RowSeparator && yCoordinate < inner.Max.Y && i != last {
+			p.Fill(horizontalCell, image.Rect(inner.Min.X, yCoordinate, inner.Max.X, yCoordinate+1))
 			yCoordinate++
 		}
 	}
 }
+
+// columnHints returns the width of the widest cell in each column.
+func (self *Table) columnHints() []int {
+	if len(self.ColumnWidths) > 0 {
+		return self.ColumnWidths
+	}
+	var widths []int
+	hintRows := len(self.Rows)
+	if self.source != nil {
+		hintRows = sourceHintRows
+	}
+	for _, row := range self.rowsIn(0, hintRows) {
+		for j, col := range row {
+			if j == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = MaxInt(widths[j], len(ParseStyles(col, self.TextStyle)))
+		}
+	}
+	return widths
+}
+
+// fitColumns returns the width of each column drawn within width. Unless
+// ColumnWidths is set, the columns are as wide as their widest cell, and then
+// share the space left equally, or give up the missing space from the widest.
+func (self *Table) fitColumns(width int) []int {
+	if len(self.ColumnWidths) > 0 {
+		return self.ColumnWidths
+	}
+	widths := append([]int(nil), self.columnHints()...)
+	n := len(widths)
+	if n == 0 {
+		return widths
+	}
+	free := width - (n - 1) - SumIntSlice(widths)
+	if free > 0 {
+		for i := range widths {
+			widths[i] += free / n
+			if i < free%n {
+				widths[i]++
+			}
+		}
+	}
+	for ; free < 0; free++ {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] == 0 {
+			break
+		}
+		widths[widest]--
+	}
+	return widths
+}
+
+// SizeHint returns the size showing every cell in full. Only the first rows
+// of a DataSource are measured.
+func (self *Table) SizeHint() image.Point {
+	widths := self.columnHints()
+	width := SumIntSlice(widths) + MaxInt(len(widths)-1, 0)
+	return image.Pt(width, self.rowsHeight(self.rowCount())).Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing a single row.
+func (self *Table) MinSizeHint() image.Point {
+	return image.Pt(len(self.columnHints()), MinInt(self.rowCount(), 1)).Add(self.FrameSize())
+}
+
+// rowsHeight returns the number of lines taken by n rows.
+func (self *Table) rowsHeight(n int) int {
+	if self.RowSeparator && n > 0 {
+		return 2*n - 1
+	}
+	return n
+}
+
+// pageRows returns the number of rows that fit in the table.
+func (self *Table) pageRows() int {
+	height := self.GetInner().Dy()
+	if self.RowSeparator {
+		return MaxInt((height+1)/2, 1)
+	}
+	return MaxInt(height, 1)
+}
+
+// maxTopRow returns the first row shown when the table is scrolled to the
+// bottom.
+func (self *Table) maxTopRow() int {
+	return MaxInt(self.rowCount()-self.pageRows(), 0)
+}
+
+// ScrollAmount scrolls by amount rows. If amount is < 0, then scroll up.
+func (self *Table) ScrollAmount(amount int) {
+	self.topRow = MaxInt(0, MinInt(self.topRow+amount, self.maxTopRow()))
+}
+
+func (self *Table) ScrollUp() {
+	self.ScrollAmount(-1)
+}
+
+func (self *Table) ScrollDown() {
+	self.ScrollAmount(1)
+}
+
+func (self *Table) ScrollPageUp() {
+	self.ScrollAmount(-self.pageRows())
+}
+
+func (self *Table) ScrollPageDown() {
+	self.ScrollAmount(self.pageRows())
+}
+
+func (self *Table) ScrollTop() {
+	self.topRow = 0
+}
+
+func (self *Table) ScrollBottom() {
+	self.topRow = self.maxTopRow()
+}
+
+// DoEvent scrolls the rows with the arrow keys, PgUp/PgDn and Home/End when
+// the table is focused, and with the mouse wheel.
+func (self *Table) DoEvent(e Event) bool {
+	switch e.Type {
+	case KeyboardEvent:
+		if !self.IsFocused() {
+			return false
+		}
+		switch e.ID {
+		case KeyArrowUp:
+			self.ScrollUp()
+		case KeyArrowDown:
+			self.ScrollDown()
+		case KeyPgup:
+			self.ScrollPageUp()
+		case KeyPgdn:
+			self.ScrollPageDown()
+		case KeyHome:
+			self.ScrollTop()
+		case KeyEnd:
+			self.ScrollBottom()
+		default:
+			return false
+		}
+	case MouseEvent:
+		n := wheelDelta(self, e)
+		if n == 0 {
+			return false
+		}
+		self.ScrollAmount(n)
+	default:
+		return false
+	}
+	self.Refresh()
+	return true
+}
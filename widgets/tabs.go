@@ -7,7 +7,7 @@ package widgets
 import (
 	"image"
 
-	. "github.com/gizak/termui/v3"
+	. "github.com/thzll/termuix"
 )
 
 // TabPane is a renderable widget which can be used to conditionally render certain tabs/views.
@@ -42,27 +42,66 @@ func (self *TabPane) FocusRight() {
 	}
 }
 
-func (self *TabPane) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
+// SizeHint returns the size showing every tab name.
+func (self *TabPane) SizeHint() image.Point {
+	var width int
+	for _, name := range self.TabNames {
+		width += len(name) + 3
+	}
+	return image.Pt(MaxInt(width-3, 0), 1).Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing a single cell.
+func (self *TabPane) MinSizeHint() image.Point {
+	return image.Pt(1, 1).Add(self.FrameSize())
+}
+
+// DoEvent switches tabs with the left and right arrow keys when the tab pane
+// is focused.
+func (self *TabPane) DoEvent(e Event) bool {
+	if e.Type != KeyboardEvent || !self.IsFocused() {
+		return false
+	}
+	switch e.ID {
+	case KeyArrowLeft:
+		self.FocusLeft()
+	case KeyArrowRight:
+		self.FocusRight()
+	default:
+		return false
+	}
+	self.Refresh()
+	return true
+}
+
+func (self *TabPane) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
 
-	xCoordinate := self.Inner.Min.X
+	xCoordinate := inner.Min.X
 	for i, name := range self.TabNames {
 		ColorPair := self.InactiveTabStyle
 		if i == self.ActiveTabIndex {
 			ColorPair = self.ActiveTabStyle
 		}
-		buf.SetString(
-			TrimString(name, self.Inner.Max.X-xCoordinate),
+		p.SetString(
+			TrimString(name, inner.Max.X-xCoordinate),
 			ColorPair,
-			image.Pt(xCoordinate, self.Inner.Min.Y),
+			image.Pt(xCoordinate, inner.Min.Y),
 		)
 
 		xCoordinate += 1 + len(name)
 
-		if i < len(self.TabNames)-1 && xCoordinate < self.Inner.Max.X {
-			buf.SetCell(
+		if i < len(self.TabNames)-1 && xCoordinate < inner.Max.X {
+			p.SetCell(
 				NewCell(VERTICAL_LINE, NewStyle(ColorWhite)),
-				image.Pt(xCoordinate, self.Inner.Min.Y),
+				image.Pt(xCoordinate, inner.Min.Y),
 			)
 		}
 
@@ -9,8 +9,9 @@ import (
 	"image"
 	"strings"
 
-	. "github.com/gizak/termui/v3"
 	rw "github.com/mattn/go-runewidth"
+
+	. "github.com/thzll/termuix"
 )
 
 const treeIndent = "  "
@@ -80,6 +81,7 @@ func (self *Tree) prepareNodes() {
 	for _, node := range self.nodes {
 		self.prepareNode(node, 0)
 	}
+	self.Invalidate()
 }
 
 func (self *Tree) prepareNode(node *TreeNode, level int) {
@@ -115,55 +117,123 @@ func (self *Tree) walk(n *TreeNode, fn TreeWalkFn) bool {
 	return true
 }
 
-func (self *Tree) Draw(buf *Buffer) {
-	self.Block.Draw(buf)
-	point := self.Inner.Min
+func (self *Tree) Draw() {
+	self.Lock()
+	defer self.Unlock()
+	self.DrawFrame()
+	p := self.GetPainter()
+	if p == nil {
+		return
+	}
+	inner := self.GetInnerRealPos()
+	point := inner.Min
 
 	// adjusts view into widget
-	if self.SelectedRow >= self.Inner.Dy()+self.topRow {
-		self.topRow = self.SelectedRow - self.Inner.Dy() + 1
+	if self.SelectedRow >= inner.Dy()+self.topRow {
+		self.topRow = self.SelectedRow - inner.Dy() + 1
 	} else if self.SelectedRow < self.topRow {
 		self.topRow = self.SelectedRow
 	}
 
 	// draw rows
-	for row := self.topRow; row < len(self.rows) && point.Y < self.Inner.Max.Y; row++ {
+	for row := self.topRow; row < len(self.rows) && point.Y < inner.Max.Y; row++ {
 		cells := self.rows[row].parseStyles(self.TextStyle)
 		if self.WrapText {
-			cells = WrapCells(cells, uint(self.Inner.Dx()))
+			cells = WrapCells(cells, uint(inner.Dx()))
 		}
-		for j := 0; j < len(cells) && point.Y < self.Inner.Max.Y; j++ {
+		for j := 0; j < len(cells) && point.Y < inner.Max.Y; j++ {
 			style := cells[j].Style
 			if row == self.SelectedRow {
 				style = self.SelectedRowStyle
 			}
-			if point.X+1 == self.Inner.Max.X+1 && len(cells) > self.Inner.Dx() {
-				buf.SetCell(NewCell(ELLIPSES, style), point.Add(image.Pt(-1, 0)))
+			if point.X+1 == inner.Max.X+1 && len(cells) > inner.Dx() {
+				p.SetCell(NewCell(ELLIPSES, style), point.Add(image.Pt(-1, 0)))
 			} else {
-				buf.SetCell(NewCell(cells[j].Rune, style), point)
+				p.SetCell(NewCell(cells[j].Rune, style), point)
 				point = point.Add(image.Pt(rw.RuneWidth(cells[j].Rune), 0))
 			}
 		}
-		point = image.Pt(self.Inner.Min.X, point.Y+1)
+		point = image.Pt(inner.Min.X, point.Y+1)
 	}
 
 	// draw UP_ARROW if needed
 	if self.topRow > 0 {
-		buf.SetCell(
+		p.SetCell(
 			NewCell(UP_ARROW, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Max.X-1, self.Inner.Min.Y),
+			image.Pt(inner.Max.X-1, inner.Min.Y),
 		)
 	}
 
 	// draw DOWN_ARROW if needed
-	if len(self.rows) > int(self.topRow)+self.Inner.Dy() {
-		buf.SetCell(
+	if len(self.rows) > int(self.topRow)+inner.Dy() {
+		p.SetCell(
 			NewCell(DOWN_ARROW, NewStyle(ColorWhite)),
-			image.Pt(self.Inner.Max.X-1, self.Inner.Max.Y-1),
+			image.Pt(inner.Max.X-1, inner.Max.Y-1),
 		)
 	}
 }
 
+// SizeHint returns the size showing every visible node in full.
+func (self *Tree) SizeHint() image.Point {
+	var width int
+	for _, node := range self.rows {
+		width = MaxInt(width, rw.StringWidth(CellsToString(node.parseStyles(self.TextStyle))))
+	}
+	return image.Pt(width, len(self.rows)).Add(self.FrameSize())
+}
+
+// MinSizeHint returns the size showing a single cell.
+func (self *Tree) MinSizeHint() image.Point {
+	return image.Pt(1, 1).Add(self.FrameSize())
+}
+
+// DoEvent moves the selection with the arrow keys, PgUp/PgDn and Home/End
+// when the tree is focused, and with the mouse wheel. Left collapses the
+// selected node, Right expands it and Enter toggles it.
+func (self *Tree) DoEvent(e Event) bool {
+	if len(self.rows) == 0 {
+		return false
+	}
+	switch e.Type {
+	case KeyboardEvent:
+		if !self.IsFocused() {
+			return false
+		}
+		switch e.ID {
+		case KeyArrowUp:
+			self.ScrollUp()
+		case KeyArrowDown:
+			self.ScrollDown()
+		case KeyPgup:
+			self.ScrollPageUp()
+		case KeyPgdn:
+			self.ScrollPageDown()
+		case KeyHome:
+			self.ScrollTop()
+		case KeyEnd:
+			self.ScrollBottom()
+		case KeyArrowLeft:
+			self.Collapse()
+		case KeyArrowRight:
+			self.Expand()
+		case KeyEnter:
+			self.ToggleExpand()
+		default:
+			return false
+		}
+	case MouseEvent:
+		n := wheelDelta(self, e)
+		if n == 0 {
+			return false
+		}
+		self.ScrollAmount(n)
+	default:
+		return false
+	}
+	self.Refresh()
+	return true
+}
+
 // ScrollAmount scrolls by amount given. If amount is < 0, then scroll up.
 // There is no need to set self.topRow, as this will be set automatically when drawn,
 // since if the selected item is off screen then the topRow variable will change accordingly.
@@ -197,20 +267,20 @@ func (self *Tree) ScrollPageUp() {
 	if self.SelectedRow > self.topRow {
 		self.SelectedRow = self.topRow
 	} else {
-		self.ScrollAmount(-self.Inner.Dy())
+		self.ScrollAmount(-self.GetInner().Dy())
 	}
 }
 
 func (self *Tree) ScrollPageDown() {
-	self.ScrollAmount(self.Inner.Dy())
+	self.ScrollAmount(self.GetInner().Dy())
 }
 
 func (self *Tree) ScrollHalfPageUp() {
-	self.ScrollAmount(-int(FloorFloat64(float64(self.Inner.Dy()) / 2)))
+	self.ScrollAmount(-int(FloorFloat64(float64(self.GetInner().Dy()) / 2)))
 }
 
 func (self *Tree) ScrollHalfPageDown() {
-	self.ScrollAmount(int(FloorFloat64(float64(self.Inner.Dy()) / 2)))
+	self.ScrollAmount(int(FloorFloat64(float64(self.GetInner().Dy()) / 2)))
 }
 
 func (self *Tree) ScrollTop() {
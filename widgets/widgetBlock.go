@@ -4,5 +4,34 @@
 
 package widgets
 
+import (
+	"image"
+
+	. "github.com/thzll/termuix"
+)
+
 type WidgetBlock struct {
 }
+
+// wheelStep is the number of rows scrolled by one turn of the mouse wheel.
+const wheelStep = 3
+
+// wheelDelta returns the number of rows the mouse wheel event e scrolls w by,
+// or 0 if e isn't a wheel event over w.
+func wheelDelta(w Widget, e Event) int {
+	if e.Type != MouseEvent {
+		return 0
+	}
+	m := e.Payload.(Mouse)
+	outer := w.GetOuter().Add(w.GetInnerRealPos().Min.Sub(w.GetInner().Min))
+	if !image.Pt(m.X, m.Y).In(outer) {
+		return 0
+	}
+	switch e.ID {
+	case "<MouseWheelUp>":
+		return -wheelStep
+	case "<MouseWheelDown>":
+		return wheelStep
+	}
+	return 0
+}